@@ -0,0 +1,323 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyRelativePath is the path of the file relative to the loaded directory, using forward slashes.
+	MetaKeyRelativePath = "_relative_path"
+)
+
+// ErrFileTooLarge is passed to OnFileError when a file exceeds DirLoaderConfig.MaxFileSize.
+var ErrFileTooLarge = errors.New("file exceeds max file size")
+
+type DirLoaderConfig struct {
+	// Parser is used to parse every file, defaults to an ExtParser falling back to TextParser.
+	Parser parser.Parser
+	// UseRelPathAsID sets the document ID to the relative path of the file,
+	// with a "_<index>" suffix when a file yields multiple documents.
+	UseRelPathAsID bool
+
+	// Recursive descends into sub directories, otherwise only the top level files are loaded.
+	Recursive bool
+	// Include keeps only files matching at least one of the globs, empty means all files.
+	// A glob containing "/" is matched against the relative path, otherwise against the file name.
+	// "**" matches any number of path segments, e.g. "docs/**/*.md".
+	Include []string
+	// Exclude drops files and directories matching any of the globs, using the same rules as Include.
+	Exclude []string
+	// UseGitignore honors .gitignore files found in the tree, the ".git" directory is always skipped.
+	UseGitignore bool
+	// MaxFileSize skips files larger than the given size in bytes, 0 means no limit.
+	MaxFileSize int64
+	// Concurrency is the number of files parsed in parallel, defaults to runtime.GOMAXPROCS(0).
+	Concurrency int
+	// OnFileError is called when a single file can not be loaded, including files over MaxFileSize.
+	// Returning nil skips the file and continues, returning an error aborts the whole load.
+	// Defaults to logging the error and skipping the file.
+	OnFileError func(ctx context.Context, path string, err error) error
+}
+
+// DirLoader loads all matching files under a local directory, Source.URI is the directory path.
+type DirLoader struct {
+	conf DirLoaderConfig
+}
+
+// NewDirLoader creates a new DirLoader.
+func NewDirLoader(ctx context.Context, config *DirLoaderConfig) (*DirLoader, error) {
+	if config == nil {
+		config = &DirLoaderConfig{}
+	}
+	conf := *config
+
+	if conf.Parser == nil {
+		p, err := parser.NewExtParser(ctx,
+			&parser.ExtParserConfig{
+				FallbackParser: parser.TextParser{},
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new file parser fail: %w", err)
+		}
+		conf.Parser = p
+	}
+	if conf.Concurrency <= 0 {
+		conf.Concurrency = runtime.GOMAXPROCS(0)
+	}
+	if conf.OnFileError == nil {
+		conf.OnFileError = func(ctx context.Context, path string, err error) error {
+			log.Printf("dir loader skip file [%s]: %v", path, err)
+			return nil
+		}
+	}
+	for _, pattern := range append(append([]string{}, conf.Include...), conf.Exclude...) {
+		if err := validateGlob(pattern); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &DirLoader{conf: conf}, nil
+}
+
+func (d *DirLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, d.GetType(), components.ComponentOfLoader)
+
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	files, err := d.walk(ctx, src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	results := make([][]*schema.Document, len(files))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		abortErr error
+		sem      = make(chan struct{}, d.conf.Concurrency)
+	)
+
+	for i, f := range files {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, f dirFile) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			fileDocs, fErr := d.loadFile(ctx, f, o.ParserOptions)
+			if fErr == nil {
+				results[i] = fileDocs
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if hErr := d.conf.OnFileError(ctx, f.path, fErr); hErr != nil {
+				once.Do(func() {
+					abortErr = hErr
+					cancel()
+				})
+			}
+		}(i, f)
+	}
+	wg.Wait()
+
+	if abortErr != nil {
+		return nil, fmt.Errorf("dir loader aborted: %w", abortErr)
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, fileDocs := range results {
+		docs = append(docs, fileDocs...)
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (d *DirLoader) GetType() string {
+	return "DirLoader"
+}
+
+func (d *DirLoader) IsCallbacksEnabled() bool {
+	return true
+}
+
+type dirFile struct {
+	path    string
+	relPath string
+}
+
+// walk collects the files to load in lexical order, files over MaxFileSize are reported to OnFileError.
+func (d *DirLoader) walk(ctx context.Context, root string) ([]dirFile, error) {
+	if len(root) == 0 {
+		return nil, errors.New("read dir from path, path is empty")
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("read dir from path, error while checking dir stat: %w, path= %s", err, root)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("read dir from path can only accept dir path, actual= %s", root)
+	}
+
+	var (
+		files  []dirFile
+		ignore = &gitignore{}
+	)
+
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if path == root {
+				return walkErr
+			}
+			return d.conf.OnFileError(ctx, path, walkErr)
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if entry.IsDir() {
+			if path == root {
+				return d.loadGitignore(ignore, path, "")
+			}
+			if !d.conf.Recursive || entry.Name() == ".git" ||
+				matchAny(d.conf.Exclude, rel) ||
+				(d.conf.UseGitignore && ignore.ignored(rel, true)) {
+				return filepath.SkipDir
+			}
+			return d.loadGitignore(ignore, path, rel)
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if len(d.conf.Include) > 0 && !matchAny(d.conf.Include, rel) {
+			return nil
+		}
+		if matchAny(d.conf.Exclude, rel) || (d.conf.UseGitignore && ignore.ignored(rel, false)) {
+			return nil
+		}
+
+		fi, err := entry.Info()
+		if err != nil {
+			return d.conf.OnFileError(ctx, path, err)
+		}
+		if d.conf.MaxFileSize > 0 && fi.Size() > d.conf.MaxFileSize {
+			return d.conf.OnFileError(ctx, path, fmt.Errorf("%w: size= %d, max= %d", ErrFileTooLarge, fi.Size(), d.conf.MaxFileSize))
+		}
+
+		files = append(files, dirFile{path: path, relPath: rel})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir [%s] failed: %w", root, err)
+	}
+
+	return files, nil
+}
+
+func (d *DirLoader) loadGitignore(ignore *gitignore, dir, rel string) error {
+	if !d.conf.UseGitignore {
+		return nil
+	}
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read .gitignore in [%s] failed: %w", dir, err)
+	}
+	ignore.add(rel, string(content))
+	return nil
+}
+
+func (d *DirLoader) loadFile(ctx context.Context, f dirFile, parserOpts []parser.Option) ([]*schema.Document, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("open file failed: %w", err)
+	}
+	defer file.Close()
+
+	meta := map[string]any{
+		MetaKeyExtension:    filepath.Ext(f.path),
+		MetaKeyFileName:     filepath.Base(f.path),
+		MetaKeySource:       f.path,
+		MetaKeyRelativePath: f.relPath,
+	}
+
+	docs, err := d.conf.Parser.Parse(ctx, file, append([]parser.Option{parser.WithURI(f.path), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("file parse err of [%s]: %w", f.path, err)
+	}
+
+	if d.conf.UseRelPathAsID {
+		if len(docs) == 1 {
+			docs[0].ID = f.relPath
+		} else {
+			for idx, doc := range docs {
+				doc.ID = fmt.Sprintf("%s_%d", f.relPath, idx)
+			}
+		}
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		assert.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	return root
+}
+
+func relPaths(docs []*schema.Document) []string {
+	var paths []string
+	for _, doc := range docs {
+		paths = append(paths, doc.MetaData[MetaKeyRelativePath].(string))
+	}
+	return paths
+}
+
+type failParser struct {
+	fail string
+}
+
+func (p *failParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	o := parser.GetCommonOptions(&parser.Options{}, opts...)
+	if strings.HasSuffix(o.URI, p.fail) {
+		return nil, errors.New("bad file")
+	}
+	return parser.TextParser{}.Parse(ctx, reader, opts...)
+}
+
+func TestDirLoader_Load(t *testing.T) {
+	ctx := context.Background()
+	root := writeTree(t, map[string]string{
+		"README.md":               "readme",
+		"main.go":                 "package main",
+		"docs/a.md":               "a",
+		"docs/guide/b.md":         "b",
+		"docs/guide/draft.md":     "draft",
+		"node_modules/pkg/c.md":   "c",
+		"build/out.md":            "out",
+		".git/HEAD":               "ref",
+		".gitignore":              "build/\n*.log\n",
+		"docs/guide/.gitignore":   "draft.md\n",
+		"docs/debug.log":          "log",
+		"docs/keep/important.log": "keep",
+		"docs/keep/.gitignore":    "!important.log\n",
+	})
+
+	t.Run("recursive with globs and gitignore", func(t *testing.T) {
+		loader, err := NewDirLoader(ctx, &DirLoaderConfig{
+			Recursive:      true,
+			UseGitignore:   true,
+			UseRelPathAsID: true,
+			Exclude:        []string{"node_modules", ".gitignore"},
+			Concurrency:    2,
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"README.md", "docs/a.md", "docs/guide/b.md", "docs/keep/important.log", "main.go"}, relPaths(docs))
+		assert.Equal(t, "docs/guide/b.md", docs[2].ID)
+		assert.Equal(t, "b", docs[2].Content)
+		assert.Equal(t, "b.md", docs[2].MetaData[MetaKeyFileName])
+		assert.Equal(t, ".md", docs[2].MetaData[MetaKeyExtension])
+		assert.Equal(t, filepath.Join(root, "docs", "guide", "b.md"), docs[2].MetaData[MetaKeySource])
+	})
+
+	t.Run("include and non recursive", func(t *testing.T) {
+		loader, err := NewDirLoader(ctx, &DirLoaderConfig{
+			Include: []string{"*.md"},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"README.md"}, relPaths(docs))
+
+		loader, err = NewDirLoader(ctx, &DirLoaderConfig{
+			Recursive: true,
+			Include:   []string{"docs/**/*.md"},
+		})
+		assert.NoError(t, err)
+
+		docs, err = loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"docs/a.md", "docs/guide/b.md", "docs/guide/draft.md"}, relPaths(docs))
+	})
+
+	t.Run("per file errors", func(t *testing.T) {
+		var (
+			mu     sync.Mutex
+			failed = map[string]error{}
+		)
+		loader, err := NewDirLoader(ctx, &DirLoaderConfig{
+			Recursive:   true,
+			Include:     []string{"docs/**"},
+			MaxFileSize: 3,
+			Parser:      &failParser{fail: "a.md"},
+			OnFileError: func(ctx context.Context, path string, err error) error {
+				mu.Lock()
+				defer mu.Unlock()
+				rel, _ := filepath.Rel(root, path)
+				failed[filepath.ToSlash(rel)] = err
+				return nil
+			},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"docs/debug.log", "docs/guide/b.md"}, relPaths(docs))
+		assert.Len(t, failed, 5)
+		assert.ErrorIs(t, failed["docs/guide/draft.md"], ErrFileTooLarge)
+		assert.ErrorIs(t, failed["docs/keep/important.log"], ErrFileTooLarge)
+		assert.ErrorIs(t, failed["docs/guide/.gitignore"], ErrFileTooLarge)
+		assert.ErrorContains(t, failed["docs/a.md"], "bad file")
+	})
+
+	t.Run("abort on error", func(t *testing.T) {
+		loader, err := NewDirLoader(ctx, &DirLoaderConfig{
+			Recursive: true,
+			Parser:    &failParser{fail: "a.md"},
+			OnFileError: func(ctx context.Context, path string, err error) error {
+				return err
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: root})
+		assert.ErrorContains(t, err, "bad file")
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		loader, err := NewDirLoader(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: filepath.Join(root, "README.md")})
+		assert.Error(t, err)
+
+		_, err = NewDirLoader(ctx, &DirLoaderConfig{Include: []string{"[a"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		rel     string
+		want    bool
+	}{
+		{"*.md", "a.md", true},
+		{"*.md", "docs/a.md", true},
+		{"*.md", "docs/a.go", false},
+		{"docs/*.md", "docs/a.md", true},
+		{"docs/*.md", "docs/sub/a.md", false},
+		{"docs/**/*.md", "docs/a.md", true},
+		{"docs/**/*.md", "docs/x/y/a.md", true},
+		{"**/test/**", "a/test/b.go", true},
+		{"/docs/*.md", "docs/a.md", true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, matchGlob(c.pattern, c.rel), "%s %s", c.pattern, c.rel)
+	}
+}
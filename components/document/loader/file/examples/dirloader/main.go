@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/file"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loader, err := file.NewDirLoader(ctx, &file.DirLoaderConfig{
+		Recursive:      true,
+		UseGitignore:   true,
+		UseRelPathAsID: true,
+		Include:        []string{"*.md"},
+		MaxFileSize:    1 << 20,
+		Concurrency:    4,
+		OnFileError: func(ctx context.Context, path string, err error) error {
+			log.Printf("skip file %s: %v", path, err)
+			return nil
+		},
+	})
+	if err != nil {
+		log.Fatalf("file.NewDirLoader failed, err=%v", err)
+	}
+
+	docs, err := loader.Load(ctx, document.Source{
+		URI: "../../testdata",
+	})
+	if err != nil {
+		log.Fatalf("loader.Load failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		log.Printf("id: %s, relative path: %s, size: %d", doc.ID, doc.MetaData[file.MetaKeyRelativePath], len(doc.Content))
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -35,14 +37,39 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+const (
+	MetaKeySource       = "_source"
+	MetaKeyBucket       = "_bucket"
+	MetaKeyObjectKey    = "_object_key"
+	MetaKeyETag         = "_etag"
+	MetaKeyLastModified = "_last_modified" // RFC3339 formatted
+	MetaKeyContentType  = "_content_type"
+	MetaKeySize         = "_size"
+	MetaKeyUserMetadata = "_user_metadata" // map[string]string of the x-amz-meta-* headers
+)
+
 // LoaderConfig is the configuration for s3 loader.
 type LoaderConfig struct {
 	Region       *string // the region of the AWS bucket
 	AWSAccessKey *string
 	AWSSecretKey *string
 
+	// Endpoint overrides the S3 endpoint, e.g. "http://localhost:9000" for MinIO or other S3-compatible stores.
+	Endpoint *string
+	// UsePathStyle addresses buckets as "endpoint/bucket/key" instead of "bucket.endpoint/key",
+	// which most on-prem object stores require.
+	UsePathStyle bool
+
 	UseObjectKeyAsID bool // whether to use object key as document ID
 
+	// Include and Exclude filter the objects listed under a prefix uri like "s3://bucket/prefix/".
+	// Globs are matched against the object key relative to the prefix, "*" does not cross "/" and "**" matches any number of segments.
+	// A glob without "/" is matched against the last segment of the key only.
+	Include []string
+	Exclude []string
+	// MaxKeys is the page size of each ListObjectsV2 call, default to the server side default (1000 for AWS).
+	MaxKeys int32
+
 	Parser parser.Parser // the parser to parse the s3 object stream into documents, default to parser.TextParser, which directly converts []byte to string
 }
 
@@ -52,6 +79,10 @@ type loader struct {
 	parser parser.Parser
 
 	useObjectKeyAsID bool
+
+	include []string
+	exclude []string
+	maxKeys int32
 }
 
 // NewS3Loader creates a new s3 loader.
@@ -88,7 +119,18 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		return nil, fmt.Errorf("new s3 loader, load config err: %w", err)
 	}
 
-	client := s3.NewFromConfig(sdkConfig)
+	for _, pattern := range append(append([]string{}, conf.Include...), conf.Exclude...) {
+		if err = validateGlob(pattern); err != nil {
+			return nil, fmt.Errorf("new s3 loader, invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	client := s3.NewFromConfig(sdkConfig, func(o *s3.Options) {
+		if conf.Endpoint != nil {
+			o.BaseEndpoint = conf.Endpoint
+		}
+		o.UsePathStyle = conf.UsePathStyle
+	})
 
 	p := conf.Parser
 	if p == nil {
@@ -99,6 +141,9 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		client:           client,
 		parser:           p,
 		useObjectKeyAsID: conf.UseObjectKeyAsID,
+		include:          conf.Include,
+		exclude:          conf.Exclude,
+		maxKeys:          conf.MaxKeys,
 	}, nil
 }
 
@@ -121,12 +166,76 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		return nil, err
 	}
 
-	// prefix is not supported now
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	keys := []string{key}
 	if isPrefix {
-		err = errors.New("s3 loader does not support batch load with prefix for now")
-		return nil, err
+		keys, err = l.listKeys(ctx, bucket, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, k := range keys {
+		objDocs, e := l.loadObject(ctx, bucket, k, o.ParserOptions)
+		if e != nil {
+			err = e
+			return nil, err
+		}
+		docs = append(docs, objDocs...)
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// listKeys lists all object keys under the prefix page by page, skipping "directory" placeholder keys.
+func (l *loader) listKeys(ctx context.Context, bucket, prefix string) ([]string, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	if len(prefix) > 0 {
+		input.Prefix = aws.String(prefix)
+	}
+	if l.maxKeys > 0 {
+		input.MaxKeys = aws.Int32(l.maxKeys)
+	}
+
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(l.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("s3 loader list objects bucket= %s, prefix= %s, err: %w", bucket, prefix, err)
+		}
+
+		for _, obj := range page.Contents {
+			k := aws.ToString(obj.Key)
+			if len(k) == 0 || strings.HasSuffix(k, "/") {
+				continue
+			}
+			if !l.match(strings.TrimPrefix(k, prefix)) {
+				continue
+			}
+			keys = append(keys, k)
+		}
+	}
+
+	return keys, nil
+}
+
+func (l *loader) match(rel string) bool {
+	if len(l.include) > 0 && !matchAny(l.include, rel) {
+		return false
 	}
+	return !matchAny(l.exclude, rel)
+}
 
+func (l *loader) loadObject(ctx context.Context, bucket, key string, parserOpts []parser.Option) ([]*schema.Document, error) {
 	// get object from s3
 	resp, err := l.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
@@ -135,37 +244,66 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 	if err != nil {
 		var noKey *types.NoSuchKey
 		if errors.As(err, &noKey) {
-			err = fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
-			return nil, err
+			return nil, fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
 		}
 
-		err = fmt.Errorf("s3 loader get object err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader get object err: %w", err)
 	}
 	defer resp.Body.Close()
 
-	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	uri := "s3://" + bucket + "/" + key
+	meta := objectMeta(bucket, key, uri, resp)
 
-	docs, err = l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(src.URI)}, o.ParserOptions...)...)
+	docs, err := l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(meta)}, parserOpts...)...)
 	if err != nil {
-		err = fmt.Errorf("s3 loader parse err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader parse err: %w", err)
 	}
 
-	if l.useObjectKeyAsID {
-		for _, doc := range docs {
+	for _, doc := range docs {
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any, len(meta))
+		}
+		for k, v := range meta {
+			if _, ok := doc.MetaData[k]; !ok {
+				doc.MetaData[k] = v
+			}
+		}
+		if l.useObjectKeyAsID {
 			doc.ID = key
 		}
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
-
 	return docs, nil
 }
 
+func objectMeta(bucket, key, uri string, resp *s3.GetObjectOutput) map[string]any {
+	meta := map[string]any{
+		MetaKeySource:    uri,
+		MetaKeyBucket:    bucket,
+		MetaKeyObjectKey: key,
+	}
+	if resp.ETag != nil {
+		meta[MetaKeyETag] = strings.Trim(*resp.ETag, `"`)
+	}
+	if resp.LastModified != nil {
+		meta[MetaKeyLastModified] = resp.LastModified.UTC().Format(time.RFC3339)
+	}
+	if resp.ContentType != nil {
+		meta[MetaKeyContentType] = *resp.ContentType
+	}
+	if resp.ContentLength != nil {
+		meta[MetaKeySize] = *resp.ContentLength
+	}
+	if len(resp.Metadata) > 0 {
+		userMeta := make(map[string]string, len(resp.Metadata))
+		for k, v := range resp.Metadata {
+			userMeta[k] = v
+		}
+		meta[MetaKeyUserMetadata] = userMeta
+	}
+	return meta
+}
+
 func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, err error) {
 	const (
 		uriPrefix = `s3://`
@@ -189,13 +327,64 @@ func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, er
 	bucket = bucketAndKey[:bucketEnd]
 	key = bucketAndKey[bucketEnd+1:]
 
-	if strings.HasSuffix(key, separator) {
+	if len(key) == 0 || strings.HasSuffix(key, separator) {
 		return bucket, key, true, nil
 	}
 
 	return bucket, key, false, nil
 }
 
+// The glob helpers below are kept in sync with loader/file/glob.go, each loader is a module of its own
+// and can't share them without depending on an unpublished module.
+
+func validateGlob(pattern string) error {
+	for _, seg := range strings.Split(pattern, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash separated relative path, patterns without "/" only look at the base name.
+func matchGlob(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(pattern, segs []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(pattern[1:], segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segs[0]); !ok {
+			return false
+		}
+		pattern, segs = pattern[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
+
 func (l *loader) GetType() string {
 	return "S3Loader"
 }
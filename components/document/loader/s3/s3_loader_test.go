@@ -18,9 +18,16 @@ package s3
 
 import (
 	"context"
+	"encoding/xml"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -95,12 +102,6 @@ func TestLoader_Load(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "incomplete")
 
-		_, err = s3Loader.Load(ctx, document.Source{
-			URI: "s3://bucket/prefix/",
-		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "does not support batch load with prefix")
-
 		mockey.PatchConvey("get object returns no such key", func() {
 			mockey.Mock((*s3.Client).GetObject).Return(nil, &types.NoSuchKey{}).Build()
 
@@ -140,3 +141,158 @@ func TestLoader_Load(t *testing.T) {
 		assert.Equal(t, "key.txt", result[0].ID)
 	})
 }
+
+type fakeObject struct {
+	body        string
+	contentType string
+	meta        map[string]string
+}
+
+type fakeContents struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int
+}
+
+type fakeListResult struct {
+	XMLName               xml.Name `xml:"ListBucketResult"`
+	Name                  string
+	Prefix                string
+	KeyCount              int
+	IsTruncated           bool
+	NextContinuationToken string `xml:",omitempty"`
+	Contents              []fakeContents
+}
+
+// newFakeS3 serves path-style ListObjectsV2 and GetObject requests for a single bucket.
+func newFakeS3(t *testing.T, bucket string, objects map[string]fakeObject, listCalls *int) *httptest.Server {
+	lastModified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+bucket || r.URL.Path == "/"+bucket+"/" {
+			*listCalls++
+			q := r.URL.Query()
+			assert.Equal(t, "2", q.Get("list-type"))
+			prefix, token := q.Get("prefix"), q.Get("continuation-token")
+			maxKeys := 1000
+			if mk := q.Get("max-keys"); mk != "" {
+				maxKeys, _ = strconv.Atoi(mk)
+			}
+
+			var keys []string
+			for k := range objects {
+				if strings.HasPrefix(k, prefix) && k > token {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			res := fakeListResult{Name: bucket, Prefix: prefix}
+			if len(keys) > maxKeys {
+				keys = keys[:maxKeys]
+				res.IsTruncated = true
+				res.NextContinuationToken = keys[len(keys)-1]
+			}
+			for _, k := range keys {
+				res.Contents = append(res.Contents, fakeContents{
+					Key:          k,
+					LastModified: lastModified.Format(time.RFC3339),
+					ETag:         `"etag-` + k + `"`,
+					Size:         len(objects[k].body),
+				})
+			}
+			res.KeyCount = len(res.Contents)
+
+			w.Header().Set("Content-Type", "application/xml")
+			_ = xml.NewEncoder(w).Encode(res)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/"+bucket+"/")
+		obj, ok := objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`))
+			return
+		}
+
+		w.Header().Set("ETag", `"etag-`+key+`"`)
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+		for k, v := range obj.meta {
+			w.Header().Set("x-amz-meta-"+k, v)
+		}
+		_, _ = w.Write([]byte(obj.body))
+	}))
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	ctx := context.Background()
+	listCalls := 0
+	server := newFakeS3(t, "bucket", map[string]fakeObject{
+		"docs/":            {},
+		"docs/a.md":        {body: "a", contentType: "text/markdown", meta: map[string]string{"author": "alice"}},
+		"docs/b.txt":       {body: "b", contentType: "text/plain"},
+		"docs/guide/c.md":  {body: "c", contentType: "text/markdown"},
+		"docs/guide/d.log": {body: "d", contentType: "text/plain"},
+		"other/e.md":       {body: "e", contentType: "text/markdown"},
+	}, &listCalls)
+	defer server.Close()
+
+	s3Loader, err := NewS3Loader(ctx, &LoaderConfig{
+		Region:           aws.String("us-east-1"),
+		AWSAccessKey:     aws.String("ak"),
+		AWSSecretKey:     aws.String("sk"),
+		Endpoint:         aws.String(server.URL),
+		UsePathStyle:     true,
+		UseObjectKeyAsID: true,
+		Exclude:          []string{"*.log"},
+		MaxKeys:          2,
+	})
+	assert.NoError(t, err)
+
+	docs, err := s3Loader.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, listCalls)
+	assert.Len(t, docs, 3)
+	assert.Equal(t, "docs/a.md", docs[0].ID)
+	assert.Equal(t, "docs/b.txt", docs[1].ID)
+	assert.Equal(t, "docs/guide/c.md", docs[2].ID)
+
+	assert.Equal(t, "a", docs[0].Content)
+	assert.Equal(t, "s3://bucket/docs/a.md", docs[0].MetaData[MetaKeySource])
+	assert.Equal(t, "bucket", docs[0].MetaData[MetaKeyBucket])
+	assert.Equal(t, "docs/a.md", docs[0].MetaData[MetaKeyObjectKey])
+	assert.Equal(t, "etag-docs/a.md", docs[0].MetaData[MetaKeyETag])
+	assert.Equal(t, "2024-01-02T03:04:05Z", docs[0].MetaData[MetaKeyLastModified])
+	assert.Equal(t, "text/markdown", docs[0].MetaData[MetaKeyContentType])
+	assert.Equal(t, int64(1), docs[0].MetaData[MetaKeySize])
+	assert.Equal(t, map[string]string{"author": "alice"}, docs[0].MetaData[MetaKeyUserMetadata])
+	assert.Nil(t, docs[1].MetaData[MetaKeyUserMetadata])
+
+	s3Loader, err = NewS3Loader(ctx, &LoaderConfig{
+		Region:       aws.String("us-east-1"),
+		AWSAccessKey: aws.String("ak"),
+		AWSSecretKey: aws.String("sk"),
+		Endpoint:     aws.String(server.URL),
+		UsePathStyle: true,
+		Include:      []string{"**/*.md"},
+	})
+	assert.NoError(t, err)
+
+	docs, err = s3Loader.Load(ctx, document.Source{URI: "s3://bucket/"})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+	assert.Equal(t, "s3://bucket/docs/a.md", docs[0].MetaData[MetaKeySource])
+	assert.Equal(t, "s3://bucket/docs/guide/c.md", docs[1].MetaData[MetaKeySource])
+	assert.Equal(t, "s3://bucket/other/e.md", docs[2].MetaData[MetaKeySource])
+
+	_, err = s3Loader.Load(ctx, document.Source{URI: "s3://bucket/missing.md"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	_, err = NewS3Loader(ctx, &LoaderConfig{Include: []string{"[a"}})
+	assert.Error(t, err)
+}
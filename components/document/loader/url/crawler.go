@@ -0,0 +1,565 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyCrawlDepth is the number of links followed from the seed url to reach the page.
+	MetaKeyCrawlDepth = "_crawl_depth"
+	// MetaKeyCanonicalURL is the canonical url of the page used for deduplication.
+	MetaKeyCanonicalURL = "_canonical_url"
+
+	defaultMaxPages        = 100
+	defaultUserAgent       = "eino-url-loader"
+	defaultRequestInterval = time.Second
+	defaultMaxBodySize     = 10 << 20
+)
+
+// CrawlConfig enables crawl mode of the url Loader, following links from the source url.
+type CrawlConfig struct {
+	// MaxDepth is the max number of links followed from the source url, 0 means only the source url (and sitemap urls).
+	MaxDepth int
+	// MaxPages is the max number of pages loaded, default 100.
+	MaxPages int
+	// AllowedDomains limits the hosts to crawl, default to the host of the source url.
+	// A domain starting with "*." also matches its sub domains, e.g. "*.example.com".
+	AllowedDomains []string
+	// AllowedPathPrefixes limits the url paths to crawl, e.g. "/docs/", empty means all paths.
+	AllowedPathPrefixes []string
+
+	// IgnoreRobotsTxt disables checking robots.txt of each host before fetching pages.
+	IgnoreRobotsTxt bool
+	// UserAgent is sent with every request and used to select the robots.txt group, default "eino-url-loader".
+	UserAgent string
+	// RequestInterval is the min interval between two requests to the same host, default 1s.
+	// A negative value disables the rate limit. A larger Crawl-delay in robots.txt takes precedence.
+	RequestInterval time.Duration
+	// MaxBodySize is the max size in bytes of a page, sitemap or robots.txt, default 10MB.
+	// A larger response fails the page, or is ignored for sitemaps and robots.txt.
+	MaxBodySize int64
+
+	// UseSitemap seeds the crawl with the urls listed in the sitemaps of the source host,
+	// taken from robots.txt or "/sitemap.xml" by default.
+	UseSitemap bool
+
+	// OnPageError is called when a page can not be fetched or parsed.
+	// Returning nil skips the page and continues, returning an error aborts the crawl.
+	// Defaults to logging the error and skipping the page.
+	OnPageError func(ctx context.Context, uri string, err error) error
+}
+
+type crawlItem struct {
+	u     *url.URL
+	depth int
+}
+
+type crawler struct {
+	loader     *Loader
+	conf       CrawlConfig
+	opts       []document.LoaderOption
+	parserOpts []parser.Option
+
+	robots      map[string]*robots
+	lastRequest map[string]time.Time
+	visited     map[string]bool
+}
+
+func (l *Loader) crawl(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	seed, err := url.Parse(src.URI)
+	if err != nil {
+		return nil, fmt.Errorf("parse crawl source uri [%s] failed: %w", src.URI, err)
+	}
+	if seed.Scheme != "http" && seed.Scheme != "https" {
+		return nil, fmt.Errorf("crawl source uri must be http(s), actual= %s", src.URI)
+	}
+
+	conf := *l.conf.Crawl
+	if conf.MaxPages <= 0 {
+		conf.MaxPages = defaultMaxPages
+	}
+	if len(conf.AllowedDomains) == 0 {
+		conf.AllowedDomains = []string{seed.Hostname()}
+	}
+	if conf.UserAgent == "" {
+		conf.UserAgent = defaultUserAgent
+	}
+	if conf.RequestInterval == 0 {
+		conf.RequestInterval = defaultRequestInterval
+	}
+	if conf.MaxBodySize <= 0 {
+		conf.MaxBodySize = defaultMaxBodySize
+	}
+	if conf.OnPageError == nil {
+		conf.OnPageError = func(ctx context.Context, uri string, err error) error {
+			log.Printf("url loader skip page [%s]: %v", uri, err)
+			return nil
+		}
+	}
+
+	c := &crawler{
+		loader:      l,
+		conf:        conf,
+		opts:        opts,
+		parserOpts:  document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...).ParserOptions,
+		robots:      make(map[string]*robots),
+		lastRequest: make(map[string]time.Time),
+		visited:     make(map[string]bool),
+	}
+
+	return c.run(ctx, seed)
+}
+
+func (c *crawler) run(ctx context.Context, seed *url.URL) ([]*schema.Document, error) {
+	var (
+		docs  []*schema.Document
+		queue []crawlItem
+		pages int
+	)
+
+	enqueue := func(u *url.URL, depth int) {
+		key := canonicalURL(u)
+		if c.visited[key] || !c.inScope(u) {
+			return
+		}
+		c.visited[key] = true
+		queue = append(queue, crawlItem{u: u, depth: depth})
+	}
+
+	enqueue(seed, 0)
+	if c.conf.UseSitemap {
+		for _, u := range c.sitemapURLs(ctx, seed) {
+			enqueue(u, 0)
+		}
+	}
+
+	for len(queue) > 0 && pages < c.conf.MaxPages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		item := queue[0]
+		queue = queue[1:]
+
+		if !c.conf.IgnoreRobotsTxt && !c.robotsFor(ctx, item.u).allowed(item.u.RequestURI()) {
+			continue
+		}
+
+		pageDocs, links, loaded, err := c.loadPage(ctx, item)
+		if err != nil {
+			if hErr := c.conf.OnPageError(ctx, item.u.String(), err); hErr != nil {
+				return nil, fmt.Errorf("crawl aborted at [%s]: %w", item.u.String(), hErr)
+			}
+			continue
+		}
+		if !loaded {
+			continue
+		}
+
+		pages++
+		docs = append(docs, pageDocs...)
+
+		if item.depth < c.conf.MaxDepth {
+			for _, link := range links {
+				enqueue(link, item.depth+1)
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// loadPage fetches and parses a page, loaded is false when the page turns out to be a duplicate.
+func (c *crawler) loadPage(ctx context.Context, item crawlItem) (docs []*schema.Document, links []*url.URL, loaded bool, err error) {
+	body, final, contentType, err := c.fetch(ctx, item.u)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	canonical := canonicalURL(final)
+	if canonical != canonicalURL(item.u) {
+		// redirected, the target may have been loaded already or be out of scope.
+		if c.visited[canonical] || !c.inScope(final) {
+			return nil, nil, false, nil
+		}
+		c.visited[canonical] = true
+	}
+
+	if isHTML(contentType, body) {
+		var declared *url.URL
+		links, declared = extractLinks(body, final)
+		if declared != nil {
+			if key := canonicalURL(declared); key != canonical {
+				if c.visited[key] {
+					return nil, nil, false, nil
+				}
+				c.visited[key] = true
+				canonical = key
+			}
+		}
+	}
+
+	docs, err = c.loader.conf.Parser.Parse(ctx, bytes.NewReader(body),
+		append([]parser.Option{parser.WithURI(final.String())}, c.parserOpts...)...)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("parse content err: %w", err)
+	}
+
+	for _, doc := range docs {
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+		doc.MetaData[MetaKeyCrawlDepth] = item.depth
+		doc.MetaData[MetaKeyCanonicalURL] = canonical
+	}
+
+	return docs, links, true, nil
+}
+
+func (c *crawler) fetch(ctx context.Context, u *url.URL) ([]byte, *url.URL, string, error) {
+	req, err := c.loader.conf.RequestBuilder(ctx, document.Source{URI: u.String()}, c.opts...)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.conf.UserAgent)
+	}
+
+	if err = c.wait(ctx, u); err != nil {
+		return nil, nil, "", err
+	}
+
+	resp, err := c.loader.conf.Client.Do(req)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := c.readBody(resp.Body)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	final := u
+	if resp.Request != nil && resp.Request.URL != nil {
+		final = resp.Request.URL
+	}
+
+	return body, final, resp.Header.Get("Content-Type"), nil
+}
+
+// get fetches a crawl helper resource such as robots.txt or a sitemap with a plain GET request.
+func (c *crawler) get(ctx context.Context, u *url.URL) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.conf.UserAgent)
+
+	if err = c.wait(ctx, u); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.loader.conf.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return c.readBody(resp.Body)
+}
+
+// readBody reads a response body, failing if it is larger than MaxBodySize.
+func (c *crawler) readBody(r io.Reader) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, c.conf.MaxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+	if int64(len(body)) > c.conf.MaxBodySize {
+		return nil, fmt.Errorf("response body exceeds max size of %d bytes", c.conf.MaxBodySize)
+	}
+	return body, nil
+}
+
+// wait blocks until the next request to the host of u is allowed by the rate limit.
+func (c *crawler) wait(ctx context.Context, u *url.URL) error {
+	host := strings.ToLower(u.Host)
+
+	interval := c.conf.RequestInterval
+	if r := c.robots[u.Scheme+"://"+host]; r != nil && r.crawlDelay > interval {
+		interval = r.crawlDelay
+	}
+
+	if last, ok := c.lastRequest[host]; ok && interval > 0 {
+		if d := time.Until(last.Add(interval)); d > 0 {
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	c.lastRequest[host] = time.Now()
+	return nil
+}
+
+// robotsFor returns the robots.txt rules of the host of u, a missing or broken robots.txt allows everything.
+func (c *crawler) robotsFor(ctx context.Context, u *url.URL) *robots {
+	key := u.Scheme + "://" + strings.ToLower(u.Host)
+	if r, ok := c.robots[key]; ok {
+		return r
+	}
+
+	r := &robots{}
+	content, err := c.get(ctx, &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"})
+	if err == nil {
+		r = parseRobots(string(content), c.conf.UserAgent)
+	}
+	c.robots[key] = r
+	return r
+}
+
+type sitemapXML struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// sitemapURLs collects page urls from the sitemaps of the seed host, following sitemap indexes.
+func (c *crawler) sitemapURLs(ctx context.Context, seed *url.URL) []*url.URL {
+	var pending []string
+	if !c.conf.IgnoreRobotsTxt {
+		pending = append(pending, c.robotsFor(ctx, seed).sitemaps...)
+	}
+	if len(pending) == 0 {
+		pending = append(pending, (&url.URL{Scheme: seed.Scheme, Host: seed.Host, Path: "/sitemap.xml"}).String())
+	}
+
+	var (
+		urls []*url.URL
+		seen = make(map[string]bool)
+	)
+	for len(pending) > 0 && len(seen) < 50 {
+		loc := pending[0]
+		pending = pending[1:]
+		if seen[loc] {
+			continue
+		}
+		seen[loc] = true
+
+		u, err := seed.Parse(loc)
+		if err != nil {
+			continue
+		}
+		content, err := c.get(ctx, u)
+		if err != nil {
+			log.Printf("url loader skip sitemap [%s]: %v", loc, err)
+			continue
+		}
+
+		var sm sitemapXML
+		if err = xml.Unmarshal(content, &sm); err != nil {
+			log.Printf("url loader skip sitemap [%s]: %v", loc, err)
+			continue
+		}
+		for _, s := range sm.Sitemaps {
+			pending = append(pending, strings.TrimSpace(s.Loc))
+		}
+		for _, entry := range sm.URLs {
+			if pu, err := u.Parse(strings.TrimSpace(entry.Loc)); err == nil {
+				urls = append(urls, pu)
+			}
+		}
+	}
+
+	return urls
+}
+
+func (c *crawler) inScope(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	domainOK := false
+	for _, d := range c.conf.AllowedDomains {
+		d = strings.ToLower(d)
+		if host == d || (strings.HasPrefix(d, "*.") && (host == d[2:] || strings.HasSuffix(host, d[1:]))) {
+			domainOK = true
+			break
+		}
+	}
+	if !domainOK {
+		return false
+	}
+
+	if len(c.conf.AllowedPathPrefixes) == 0 {
+		return true
+	}
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	for _, prefix := range c.conf.AllowedPathPrefixes {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalURL normalizes u for deduplication: lower case scheme and host, no default port,
+// no fragment, "/" for an empty path and sorted query parameters.
+func canonicalURL(u *url.URL) string {
+	c := *u
+	c.Scheme = strings.ToLower(c.Scheme)
+	c.Host = strings.ToLower(c.Host)
+	if (c.Scheme == "http" && c.Port() == "80") || (c.Scheme == "https" && c.Port() == "443") {
+		c.Host = c.Hostname()
+	}
+	c.Fragment, c.RawFragment = "", ""
+	if c.Path == "" {
+		c.Path, c.RawPath = "/", ""
+	}
+	if c.RawQuery != "" {
+		c.RawQuery = c.Query().Encode()
+	}
+	c.User = nil
+	return c.String()
+}
+
+func isHTML(contentType string, body []byte) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	return strings.Contains(strings.ToLower(contentType), "html")
+}
+
+// extractLinks returns the absolute links of <a href> elements and the <link rel="canonical"> url if any.
+// A "nofollow" robots meta tag drops all links.
+func extractLinks(body []byte, pageURL *url.URL) ([]*url.URL, *url.URL) {
+	var (
+		hrefs     []string
+		canonical string
+		base      string
+		nofollow  bool
+	)
+
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		if !hasAttr {
+			continue
+		}
+		attrs := make(map[string]string)
+		for {
+			k, v, more := z.TagAttr()
+			attrs[string(k)] = string(v)
+			if !more {
+				break
+			}
+		}
+
+		switch string(name) {
+		case "a":
+			if href, ok := attrs["href"]; ok && !strings.Contains(strings.ToLower(attrs["rel"]), "nofollow") {
+				hrefs = append(hrefs, href)
+			}
+		case "base":
+			if href, ok := attrs["href"]; ok && base == "" {
+				base = href
+			}
+		case "link":
+			if strings.EqualFold(strings.TrimSpace(attrs["rel"]), "canonical") {
+				canonical = attrs["href"]
+			}
+		case "meta":
+			if strings.EqualFold(attrs["name"], "robots") && strings.Contains(strings.ToLower(attrs["content"]), "nofollow") {
+				nofollow = true
+			}
+		}
+	}
+
+	baseURL := pageURL
+	if base != "" {
+		if b, err := pageURL.Parse(strings.TrimSpace(base)); err == nil {
+			baseURL = b
+		}
+	}
+
+	var canonicalU *url.URL
+	if canonical != "" {
+		if cu, err := baseURL.Parse(strings.TrimSpace(canonical)); err == nil {
+			canonicalU = cu
+		}
+	}
+
+	if nofollow {
+		return nil, canonicalU
+	}
+
+	links := make([]*url.URL, 0, len(hrefs))
+	for _, href := range hrefs {
+		href = strings.TrimSpace(href)
+		if href == "" || strings.HasPrefix(href, "#") {
+			continue
+		}
+		if u, err := baseURL.Parse(href); err == nil {
+			links = append(links, u)
+		}
+	}
+
+	return links, canonicalU
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+func newTestSite(t *testing.T) (*httptest.Server, *[]string) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	pages := map[string]string{
+		"/robots.txt": "User-agent: *\nDisallow: /docs/private\nSitemap: /sitemap.xml\n",
+		"/sitemap.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>/docs/orphan</loc></url>
+  <url><loc>/blog/post</loc></url>
+</urlset>`,
+		"/docs/": `<html><body>
+<a href="a">A</a>
+<a href="/docs/b#section">B</a>
+<a href="/docs/private/secret">Secret</a>
+<a href="/blog/">Blog</a>
+<a href="http://other.example.com/docs/x">External</a>
+<a href="mailto:someone@example.com">Mail</a>
+<a href="/docs/A?y=2&x=1" rel="nofollow">Skipped</a>
+</body></html>`,
+		"/docs/a":      `<html><body><a href="/docs/deep">Deep</a><a href="/docs/">Back</a></body></html>`,
+		"/docs/b":      `<html><head><link rel="canonical" href="/docs/a"></head><body>dup of a</body></html>`,
+		"/docs/deep":   `<html><body>too deep</body></html>`,
+		"/docs/orphan": `<html><body>orphan</body></html>`,
+		"/blog/":       `<html><body>blog</body></html>`,
+		"/blog/post":   `<html><body>post</body></html>`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.RequestURI())
+		mu.Unlock()
+
+		assert.Equal(t, "test-bot", r.Header.Get("User-Agent"))
+		if r.URL.Path == "/docs" {
+			http.Redirect(w, r, "/docs/", http.StatusMovedPermanently)
+			return
+		}
+		content, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path == "/sitemap.xml" {
+			w.Header().Set("Content-Type", "application/xml")
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+
+	return server, &requests
+}
+
+func docURLs(docs []*schema.Document) []string {
+	var urls []string
+	for _, doc := range docs {
+		u, _ := url.Parse(doc.MetaData[MetaKeyCanonicalURL].(string))
+		urls = append(urls, u.RequestURI())
+	}
+	return urls
+}
+
+func TestCrawl(t *testing.T) {
+	ctx := context.Background()
+	server, requests := newTestSite(t)
+	defer server.Close()
+
+	t.Run("depth, scope, robots and dedupe", func(t *testing.T) {
+		loader, err := NewLoader(ctx, &LoaderConfig{
+			Parser: parser.TextParser{},
+			Crawl: &CrawlConfig{
+				MaxDepth:            1,
+				AllowedPathPrefixes: []string{"/docs"},
+				UserAgent:           "test-bot",
+				RequestInterval:     -1,
+				UseSitemap:          true,
+			},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: server.URL + "/docs"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/docs/", "/docs/orphan", "/docs/a"}, docURLs(docs))
+		assert.Equal(t, 0, docs[0].MetaData[MetaKeyCrawlDepth])
+		assert.Equal(t, 1, docs[2].MetaData[MetaKeyCrawlDepth])
+		assert.Equal(t, server.URL+"/docs/a", docs[2].MetaData[parser.MetaKeySource])
+		assert.NotContains(t, *requests, "/docs/private/secret")
+		assert.NotContains(t, *requests, "/docs/deep")
+		assert.Contains(t, *requests, "/docs/b")
+	})
+
+	t.Run("max pages and rate limit", func(t *testing.T) {
+		loader, err := NewLoader(ctx, &LoaderConfig{
+			Parser: parser.TextParser{},
+			Crawl: &CrawlConfig{
+				MaxDepth:        5,
+				MaxPages:        3,
+				UserAgent:       "test-bot",
+				IgnoreRobotsTxt: true,
+				RequestInterval: 20 * time.Millisecond,
+			},
+		})
+		assert.NoError(t, err)
+
+		start := time.Now()
+		docs, err := loader.Load(ctx, document.Source{URI: server.URL + "/docs/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/docs/", "/docs/a", "/blog/"}, docURLs(docs))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("page errors", func(t *testing.T) {
+		var failed []string
+		loader, err := NewLoader(ctx, &LoaderConfig{
+			Parser: parser.TextParser{},
+			Crawl: &CrawlConfig{
+				UserAgent:       "test-bot",
+				RequestInterval: -1,
+				UseSitemap:      true,
+				OnPageError: func(ctx context.Context, uri string, err error) error {
+					failed = append(failed, uri)
+					return nil
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: server.URL + "/missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{server.URL + "/missing"}, failed)
+
+		loader, err = NewLoader(ctx, &LoaderConfig{
+			Parser: parser.TextParser{},
+			Crawl: &CrawlConfig{
+				UserAgent:       "test-bot",
+				RequestInterval: -1,
+				OnPageError: func(ctx context.Context, uri string, err error) error {
+					return errors.New("stop")
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: server.URL + "/missing"})
+		assert.ErrorContains(t, err, "stop")
+
+		_, err = loader.Load(ctx, document.Source{URI: "file:///tmp/a.html"})
+		assert.Error(t, err)
+	})
+
+	t.Run("max body size", func(t *testing.T) {
+		var pageErr error
+		loader, err := NewLoader(ctx, &LoaderConfig{
+			Parser: parser.TextParser{},
+			Crawl: &CrawlConfig{
+				UserAgent:       "test-bot",
+				RequestInterval: -1,
+				MaxBodySize:     16,
+				OnPageError: func(ctx context.Context, uri string, err error) error {
+					pageErr = err
+					return nil
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: server.URL + "/docs/orphan"})
+		assert.NoError(t, err)
+		assert.Empty(t, docs)
+		assert.ErrorContains(t, pageErr, "exceeds max size of 16 bytes")
+	})
+}
+
+func TestRobots(t *testing.T) {
+	r := parseRobots(`
+User-agent: *
+Disallow: /
+
+User-agent: test-bot
+User-agent: other-bot
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+Crawl-delay: 1.5
+`, "Mozilla/5.0 (compatible; test-bot/1.0)")
+
+	assert.True(t, r.allowed("/docs"))
+	assert.False(t, r.allowed("/private/x"))
+	assert.True(t, r.allowed("/private/public/x"))
+	assert.False(t, r.allowed("/files/a.pdf"))
+	assert.True(t, r.allowed("/files/a.pdf?download=1"))
+	assert.Equal(t, 1500*time.Millisecond, r.crawlDelay)
+
+	r = parseRobots("User-agent: *\nDisallow: /\n", "another-bot")
+	assert.False(t, r.allowed("/docs"))
+
+	r = parseRobots("", "another-bot")
+	assert.True(t, r.allowed("/docs"))
+}
+
+func TestCanonicalURL(t *testing.T) {
+	cases := map[string]string{
+		"HTTP://Example.com:80":           "http://example.com/",
+		"https://example.com:443/a#frag":  "https://example.com/a",
+		"https://example.com/a?b=2&a=1":   "https://example.com/a?a=1&b=2",
+		"https://user@example.com:8443/a": "https://example.com:8443/a",
+	}
+	for raw, want := range cases {
+		u, err := url.Parse(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, want, canonicalURL(u))
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// robots is the subset of a robots.txt that applies to one user agent.
+type robots struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+	sitemaps   []string
+}
+
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// parseRobots parses a robots.txt, keeping the rules of the most specific group matching userAgent,
+// falling back to the "*" group.
+func parseRobots(content, userAgent string) *robots {
+	var (
+		groups   []*robotsGroup
+		cur      *robotsGroup
+		sitemaps []string
+		inAgents bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				cur = &robotsGroup{}
+				groups = append(groups, cur)
+			}
+			cur.agents = append(cur.agents, strings.ToLower(value))
+			inAgents = true
+		case "allow", "disallow":
+			inAgents = false
+			if cur == nil || (key == "disallow" && value == "") {
+				continue
+			}
+			cur.rules = append(cur.rules, robotsRule{
+				allow:   key == "allow",
+				pattern: value,
+				re:      robotsPattern(value),
+			})
+		case "crawl-delay":
+			inAgents = false
+			if cur == nil {
+				continue
+			}
+			if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+				cur.crawlDelay = time.Duration(secs * float64(time.Second))
+			}
+		case "sitemap":
+			sitemaps = append(sitemaps, value)
+		default:
+			inAgents = false
+		}
+	}
+
+	r := &robots{sitemaps: sitemaps}
+	if g := selectRobotsGroup(groups, strings.ToLower(userAgent)); g != nil {
+		r.rules = g.rules
+		r.crawlDelay = g.crawlDelay
+	}
+	return r
+}
+
+func selectRobotsGroup(groups []*robotsGroup, userAgent string) *robotsGroup {
+	var (
+		best    *robotsGroup
+		bestLen = -1
+	)
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			l := -1
+			if agent == "*" {
+				l = 0
+			} else if agent != "" && strings.Contains(userAgent, agent) {
+				l = len(agent)
+			}
+			if l > bestLen {
+				best, bestLen = g, l
+			}
+		}
+	}
+	return best
+}
+
+// robotsPattern compiles a robots path pattern, "*" matches any sequence and a trailing "$" anchors the end.
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// allowed reports whether the path (with query) may be fetched,
+// the longest matching rule wins and allow wins a tie.
+func (r *robots) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	var (
+		allow   = true
+		bestLen = -1
+	)
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		l := len(rule.pattern)
+		if l > bestLen || (l == bestLen && rule.allow) {
+			allow, bestLen = rule.allow, l
+		}
+	}
+	return allow
+}
@@ -43,6 +43,9 @@ type LoaderConfig struct {
 
 	// optional, default GET uri.
 	RequestBuilder func(ctx context.Context, source document.Source, opts ...document.LoaderOption) (*http.Request, error)
+
+	// optional, crawl from the source uri following links when set, otherwise only the source uri is loaded.
+	Crawl *CrawlConfig
 }
 
 func defaultRequestBuilder(ctx context.Context, source document.Source, opts ...document.LoaderOption) (*http.Request, error) {
@@ -93,6 +96,24 @@ func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document
 		}
 	}()
 
+	if l.conf.Parser == nil {
+		return nil, errors.New("parser is nil")
+	}
+
+	if l.conf.Crawl != nil {
+		docs, err = l.crawl(ctx, src, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to crawl from uri [%s]: %w", src.URI, err)
+		}
+
+		_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+			Source: src,
+			Docs:   docs,
+		})
+
+		return docs, nil
+	}
+
 	var readerCloser io.ReadCloser
 	readerCloser, err = l.load(ctx, src)
 	if err != nil {
@@ -100,10 +121,6 @@ func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document
 	}
 	defer readerCloser.Close()
 
-	if l.conf.Parser == nil {
-		return nil, errors.New("parser is nil")
-	}
-	
 	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
 
 	docs, err = l.conf.Parser.Parse(ctx, readerCloser, append([]parser.Option{parser.WithURI(src.URI)}, o.ParserOptions...)...)
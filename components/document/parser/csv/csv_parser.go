@@ -0,0 +1,300 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"context"
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"golang.org/x/text/encoding"
+)
+
+const (
+	MetaDataRow  = "_row"
+	MetaDataExt  = "_ext"
+	MetaDataLine = "_line"
+)
+
+var _ parser.Parser = (*CSVParser)(nil)
+
+// Config is the configuration for CSV parser.
+type Config struct {
+	// Delimiter is the field delimiter.
+	// If not set, it is '\t' for URIs ending with .tsv or .tab, and ',' otherwise.
+	Delimiter rune
+	// Comment, if set, is the character that starts a comment line, which is skipped.
+	Comment rune
+	// LazyQuotes allows quotes to appear in unquoted fields, and non-doubled quotes in quoted fields.
+	LazyQuotes bool
+	// NoHeader is set to false by default, which means that the first row is used as the header.
+	NoHeader bool
+	// Columns names the columns when NoHeader is true, default column_1, column_2, ...
+	Columns []string
+	// Encoding forces the encoding of the input.
+	// If not set, the encoding is detected from the byte order mark,
+	// falling back to FallbackEncoding when the input is not valid UTF-8.
+	Encoding encoding.Encoding
+	// FallbackEncoding is used when Encoding is not set and the input is neither marked by a BOM nor valid UTF-8.
+	// Default is Windows-1252.
+	FallbackEncoding encoding.Encoding
+	// ContentTemplate is a text/template rendering the content of each row document.
+	// The template data is a map from column name to cell value, e.g. "{{.name}} is {{.age}} years old".
+	// If not set, the cells are joined with '\t'.
+	ContentTemplate string
+	// IDColumn is the column whose value is used as document ID.
+	// Rows with an empty value in this column fall back to the generated ID.
+	IDColumn string
+	// IDPrefix is set to customize the prefix of document ID, default 1,2,3, ...
+	IDPrefix string
+}
+
+// CSVParser parses CSV and TSV content, producing one document per row.
+// Rows are read one at a time, so ParseStream can process inputs larger than memory.
+type CSVParser struct {
+	conf *Config
+	tpl  *template.Template
+}
+
+// NewCSVParser creates a new CSV parser.
+func NewCSVParser(ctx context.Context, config *Config) (*CSVParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	p := &CSVParser{conf: config}
+	if config.ContentTemplate != "" {
+		tpl, err := template.New("content").Option("missingkey=zero").Parse(config.ContentTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("csv parser parse content template failed: %w", err)
+		}
+		p.tpl = tpl
+	}
+
+	return p, nil
+}
+
+// Parse parses all rows of the CSV content from io.Reader.
+func (p *CSVParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	rr, err := p.newRowReader(reader, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+	for {
+		doc, err := rr.next()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// ParseStream parses the CSV content from io.Reader row by row, sending each row document to the returned stream.
+// Errors while reading the header are returned immediately, later errors are sent through the stream, which is then closed.
+// Closing the returned stream stops reading.
+func (p *CSVParser) ParseStream(ctx context.Context, reader io.Reader, opts ...parser.Option) (*schema.StreamReader[*schema.Document], error) {
+	rr, err := p.newRowReader(reader, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, sw := schema.Pipe[*schema.Document](defaultStreamBuffer)
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				sw.Send(nil, fmt.Errorf("csv parser panic: %v", e))
+			}
+			sw.Close()
+		}()
+
+		for {
+			if err := ctx.Err(); err != nil {
+				sw.Send(nil, err)
+				return
+			}
+
+			doc, err := rr.next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if closed := sw.Send(doc, err); closed || err != nil {
+				return
+			}
+		}
+	}()
+
+	return sr, nil
+}
+
+const defaultStreamBuffer = 64
+
+type rowReader struct {
+	p       *CSVParser
+	r       *stdcsv.Reader
+	headers []string
+	idCol   int
+	index   int
+	extra   map[string]any
+}
+
+func (p *CSVParser) newRowReader(reader io.Reader, opts ...parser.Option) (*rowReader, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	decoded, err := decodeReader(reader, p.conf.Encoding, p.conf.FallbackEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("csv parser detect encoding failed: %w", err)
+	}
+
+	r := stdcsv.NewReader(decoded)
+	r.Comma = p.conf.Delimiter
+	if r.Comma == 0 {
+		r.Comma = delimiterOf(option.URI)
+	}
+	r.Comment = p.conf.Comment
+	r.LazyQuotes = p.conf.LazyQuotes
+	r.FieldsPerRecord = -1
+
+	rr := &rowReader{
+		p:     p,
+		r:     r,
+		idCol: -1,
+		extra: option.ExtraMeta,
+	}
+
+	if !p.conf.NoHeader {
+		headers, err := r.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("csv parser read header failed: %w", err)
+		}
+		rr.headers = make([]string, len(headers))
+		for i, h := range headers {
+			rr.headers[i] = strings.TrimSpace(h)
+		}
+		rr.index = 1
+	} else {
+		rr.headers = append(rr.headers, p.conf.Columns...)
+	}
+
+	if p.conf.IDColumn != "" {
+		for i, h := range rr.headers {
+			if h == p.conf.IDColumn {
+				rr.idCol = i
+				break
+			}
+		}
+		var n int
+		if rr.idCol < 0 {
+			// columns without a header are addressed by their generated names.
+			if _, err = fmt.Sscanf(p.conf.IDColumn, "column_%d", &n); err == nil && n > 0 {
+				rr.idCol = n - 1
+			}
+		}
+		if rr.idCol < 0 {
+			return nil, fmt.Errorf("csv parser id column not found: %s", p.conf.IDColumn)
+		}
+	}
+
+	return rr, nil
+}
+
+// next returns the document of the next row, or io.EOF when all rows are read.
+func (rr *rowReader) next() (*schema.Document, error) {
+	record, err := rr.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("csv parser read row failed: %w", err)
+	}
+	line, _ := rr.r.FieldPos(0)
+
+	index := rr.index
+	rr.index++
+
+	row := make(map[string]string, len(record))
+	for j, cell := range record {
+		row[rr.columnName(j)] = cell
+	}
+
+	var content string
+	if rr.p.tpl != nil {
+		var sb strings.Builder
+		if err = rr.p.tpl.Execute(&sb, row); err != nil {
+			return nil, fmt.Errorf("csv parser render content of line %d failed: %w", line, err)
+		}
+		content = sb.String()
+	} else {
+		parts := make([]string, len(record))
+		for j, cell := range record {
+			parts[j] = strings.TrimSpace(cell)
+		}
+		content = strings.Join(parts, "\t")
+	}
+
+	rowMeta := make(map[string]any, len(row))
+	for k, v := range row {
+		rowMeta[k] = v
+	}
+
+	meta := map[string]any{
+		MetaDataRow:  rowMeta,
+		MetaDataLine: line,
+	}
+	if rr.extra != nil {
+		meta[MetaDataExt] = rr.extra
+	}
+
+	id := fmt.Sprintf("%d", index)
+	if rr.idCol >= 0 && rr.idCol < len(record) && strings.TrimSpace(record[rr.idCol]) != "" {
+		id = strings.TrimSpace(record[rr.idCol])
+	}
+
+	return &schema.Document{
+		ID:       rr.p.conf.IDPrefix + id,
+		Content:  content,
+		MetaData: meta,
+	}, nil
+}
+
+// columnName returns the header of the j-th column, or a generated name for columns without one.
+func (rr *rowReader) columnName(j int) string {
+	if j < len(rr.headers) && rr.headers[j] != "" {
+		return rr.headers[j]
+	}
+	return fmt.Sprintf("column_%d", j+1)
+}
+
+func delimiterOf(uri string) rune {
+	switch strings.ToLower(filepath.Ext(uri)) {
+	case ".tsv", ".tab":
+		return '\t'
+	default:
+		return ','
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestCSVParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TestCSVParser_WithDefault", func(t *testing.T) {
+		f, err := os.Open("./testdata/people.csv")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewCSVParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, "1001\tAlice\t30", docs[0].Content)
+		assert.Equal(t, map[string]any{"id": "1001", "name": "Alice", "age": "30"}, docs[0].MetaData[MetaDataRow])
+		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData[MetaDataExt])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataLine])
+		assert.Equal(t, "Bob, Jr.", docs[1].MetaData[MetaDataRow].(map[string]any)["name"])
+	})
+
+	t.Run("TestCSVParser_WithTemplateAndIDColumn", func(t *testing.T) {
+		f, err := os.Open("./testdata/people.tsv")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewCSVParser(ctx, &Config{
+			ContentTemplate: "{{.name}} is {{.age}} years old",
+			IDColumn:        "id",
+			IDPrefix:        "person_",
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("./testdata/people.tsv"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "person_1001", docs[0].ID)
+		assert.Equal(t, "Alice is 30 years old", docs[0].Content)
+		assert.Equal(t, "person_1002", docs[1].ID)
+		assert.Equal(t, "Bob is 25 years old", docs[1].Content)
+	})
+
+	t.Run("TestCSVParser_WithNoHeader", func(t *testing.T) {
+		p, err := NewCSVParser(ctx, &Config{
+			NoHeader:  true,
+			Delimiter: ';',
+			IDColumn:  "column_2",
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("a;x\nb;y;extra\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "x", docs[0].ID)
+		assert.Equal(t, map[string]any{"column_1": "b", "column_2": "y", "column_3": "extra"}, docs[1].MetaData[MetaDataRow])
+	})
+
+	t.Run("TestCSVParser_WithEncoding", func(t *testing.T) {
+		gbk, err := simplifiedchinese.GBK.NewEncoder().String("姓名,年龄\n张三,21\n")
+		assert.NoError(t, err)
+
+		p, err := NewCSVParser(ctx, &Config{FallbackEncoding: simplifiedchinese.GBK})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(gbk))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, map[string]any{"姓名": "张三", "年龄": "21"}, docs[0].MetaData[MetaDataRow])
+
+		docs, err = p.Parse(ctx, strings.NewReader("\xEF\xBB\xBFname\nAlice\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Alice"}, docs[0].MetaData[MetaDataRow])
+	})
+
+	t.Run("TestCSVParser_WithUnknownIDColumn", func(t *testing.T) {
+		p, err := NewCSVParser(ctx, &Config{IDColumn: "unknown"})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("id,name\n1,a\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("TestCSVParser_WithInvalidTemplate", func(t *testing.T) {
+		_, err := NewCSVParser(ctx, &Config{ContentTemplate: "{{.name"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCSVParser_ParseStream(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := NewCSVParser(ctx, nil)
+	assert.NoError(t, err)
+
+	t.Run("TestCSVParser_StreamAllRows", func(t *testing.T) {
+		var sb strings.Builder
+		sb.WriteString("id,value\n")
+		for i := 0; i < 1000; i++ {
+			sb.WriteString("1,v\n")
+		}
+
+		sr, err := p.ParseStream(ctx, strings.NewReader(sb.String()))
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		count := 0
+		for {
+			doc, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "1\tv", doc.Content)
+			count++
+		}
+		assert.Equal(t, 1000, count)
+	})
+
+	t.Run("TestCSVParser_StreamError", func(t *testing.T) {
+		sr, err := p.ParseStream(ctx, strings.NewReader("id,value\n1,\"broken\n"))
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		_, err = sr.Recv()
+		assert.Error(t, err)
+		_, err = sr.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
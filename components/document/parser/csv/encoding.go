@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	xunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// sniffLen is the size of the prefix inspected to detect the encoding.
+const sniffLen = 64 * 1024
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// decodeReader wraps the reader to decode its content to UTF-8.
+// Without an explicit encoding, a byte order mark selects UTF-8 or UTF-16,
+// otherwise the content is kept as is if its prefix is valid UTF-8, and decoded with the fallback encoding if not.
+func decodeReader(r io.Reader, enc, fallback encoding.Encoding) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	if enc != nil {
+		return transform.NewReader(br, enc.NewDecoder()), nil
+	}
+
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	complete := errors.Is(err, io.EOF)
+
+	switch {
+	case bytes.HasPrefix(head, bomUTF8):
+		if _, err = br.Discard(len(bomUTF8)); err != nil {
+			return nil, err
+		}
+		return br, nil
+	case bytes.HasPrefix(head, bomUTF16LE):
+		return transform.NewReader(br, xunicode.UTF16(xunicode.LittleEndian, xunicode.ExpectBOM).NewDecoder()), nil
+	case bytes.HasPrefix(head, bomUTF16BE):
+		return transform.NewReader(br, xunicode.UTF16(xunicode.BigEndian, xunicode.ExpectBOM).NewDecoder()), nil
+	}
+
+	if !complete {
+		// the prefix may end in the middle of a multi-byte character.
+		for i := len(head) - 1; i >= 0 && i >= len(head)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(head[i]) {
+				if !utf8.FullRune(head[i:]) {
+					head = head[:i]
+				}
+				break
+			}
+		}
+	}
+	if utf8.Valid(head) {
+		return br, nil
+	}
+
+	if fallback == nil {
+		fallback = charmap.Windows1252
+	}
+	return transform.NewReader(br, fallback.NewDecoder()), nil
+}
@@ -0,0 +1,343 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource       = "_source"
+	MetaKeyTitle        = "_title"
+	MetaKeyAuthor       = "_author"
+	MetaKeyLanguage     = "_language"
+	MetaKeyIdentifier   = "_identifier"
+	MetaKeyPublisher    = "_publisher"
+	MetaKeyDate         = "_date"
+	MetaKeyDescription  = "_description"
+	MetaKeySubjects     = "_subjects"
+	MetaKeyChapter      = "_chapter"
+	MetaKeyChapterTitle = "_chapter_title"
+	MetaKeyChapterHref  = "_chapter_href"
+)
+
+const containerPart = "META-INF/container.xml"
+
+var _ parser.Parser = (*EPUBParser)(nil)
+
+// Config is the configuration for EPUB parser.
+type Config struct {
+	// SkipNonLinear skips the spine items marked linear="no", such as covers, footnotes and answer keys.
+	SkipNonLinear bool
+}
+
+// EPUBParser reads from io.Reader and parses EPUB content, producing one document per chapter in reading order.
+// The metadata of the book (title, author, language ...) from the OPF package document is added to every chapter,
+// and the chapter title is taken from the table of contents, falling back to the first heading of the chapter.
+// Chapters without text, such as image-only covers, are skipped.
+type EPUBParser struct {
+	conf *Config
+}
+
+// NewEPUBParser creates a new EPUB parser.
+func NewEPUBParser(ctx context.Context, config *Config) (*EPUBParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	return &EPUBParser{conf: config}, nil
+}
+
+// Parse parses the EPUB content from io.Reader.
+func (ep *EPUBParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("epub parser read all from reader failed: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open epub archive failed: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	opfPath, err := findPackagePath(files)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &opfPackage{}
+	if err = decodeXML(files, opfPath, pkg); err != nil {
+		return nil, err
+	}
+	opfDir := path.Dir(opfPath)
+
+	bookMeta := pkg.bookMeta()
+	bookMeta[MetaKeySource] = option.URI
+	for k, v := range option.ExtraMeta {
+		bookMeta[k] = v
+	}
+
+	items := make(map[string]opfItem, len(pkg.Manifest.Items))
+	for _, item := range pkg.Manifest.Items {
+		items[item.ID] = item
+	}
+
+	tocTitles := readTOC(files, pkg, items, opfDir)
+
+	var docs []*schema.Document
+	for _, ref := range pkg.Spine.ItemRefs {
+		if ep.conf.SkipNonLinear && ref.Linear == "no" {
+			continue
+		}
+		item, ok := items[ref.IDRef]
+		if !ok {
+			continue
+		}
+		href := resolveHref(opfDir, item.Href)
+		f, ok := files[href]
+		if !ok {
+			continue
+		}
+
+		ch, err := readChapter(f)
+		if err != nil {
+			return nil, fmt.Errorf("read epub chapter failed: %w, href= %s", err, href)
+		}
+		if ch.text == "" {
+			continue
+		}
+
+		meta := make(map[string]any, len(bookMeta)+3)
+		for k, v := range bookMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyChapter] = len(docs) + 1
+		meta[MetaKeyChapterHref] = href
+		if title := tocTitles[href]; title != "" {
+			meta[MetaKeyChapterTitle] = title
+		} else if ch.title != "" {
+			meta[MetaKeyChapterTitle] = ch.title
+		}
+
+		docs = append(docs, &schema.Document{
+			Content:  ch.text,
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+type opfPackage struct {
+	Metadata struct {
+		Titles       []string `xml:"title"`
+		Creators     []string `xml:"creator"`
+		Languages    []string `xml:"language"`
+		Identifiers  []string `xml:"identifier"`
+		Publishers   []string `xml:"publisher"`
+		Dates        []string `xml:"date"`
+		Descriptions []string `xml:"description"`
+		Subjects     []string `xml:"subject"`
+	} `xml:"metadata"`
+	Manifest struct {
+		Items []opfItem `xml:"item"`
+	} `xml:"manifest"`
+	Spine struct {
+		TOC      string `xml:"toc,attr"`
+		ItemRefs []struct {
+			IDRef  string `xml:"idref,attr"`
+			Linear string `xml:"linear,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+func (pkg *opfPackage) bookMeta() map[string]any {
+	meta := make(map[string]any)
+	md := pkg.Metadata
+
+	first := func(key string, values []string) {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				meta[key] = v
+				return
+			}
+		}
+	}
+	all := func(values []string) []string {
+		var ret []string
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				ret = append(ret, v)
+			}
+		}
+		return ret
+	}
+
+	first(MetaKeyTitle, md.Titles)
+	first(MetaKeyLanguage, md.Languages)
+	first(MetaKeyIdentifier, md.Identifiers)
+	first(MetaKeyPublisher, md.Publishers)
+	first(MetaKeyDate, md.Dates)
+	first(MetaKeyDescription, md.Descriptions)
+	if authors := all(md.Creators); len(authors) > 0 {
+		meta[MetaKeyAuthor] = strings.Join(authors, ", ")
+	}
+	if subjects := all(md.Subjects); len(subjects) > 0 {
+		meta[MetaKeySubjects] = subjects
+	}
+
+	return meta
+}
+
+// findPackagePath returns the path of the OPF package document declared by the container.
+func findPackagePath(files map[string]*zip.File) (string, error) {
+	container := struct {
+		RootFiles []struct {
+			FullPath  string `xml:"full-path,attr"`
+			MediaType string `xml:"media-type,attr"`
+		} `xml:"rootfiles>rootfile"`
+	}{}
+	if err := decodeXML(files, containerPart, &container); err != nil {
+		return "", err
+	}
+
+	for _, rf := range container.RootFiles {
+		if rf.MediaType == "" || rf.MediaType == "application/oebps-package+xml" {
+			return rf.FullPath, nil
+		}
+	}
+	return "", fmt.Errorf("epub package document not found in %s", containerPart)
+}
+
+// readTOC maps chapter paths to their titles in the table of contents,
+// read from the EPUB 3 navigation document, or the EPUB 2 NCX if there is none.
+// The TOC is only informative, so a missing or malformed one yields no titles.
+func readTOC(files map[string]*zip.File, pkg *opfPackage, items map[string]opfItem, opfDir string) map[string]string {
+	titles := make(map[string]string)
+
+	add := func(base, src, label string) {
+		label = strings.Join(strings.Fields(label), " ")
+		if label == "" || src == "" {
+			return
+		}
+		src, _, _ = strings.Cut(src, "#")
+		href := resolveHref(base, src)
+		if _, ok := titles[href]; !ok {
+			titles[href] = label
+		}
+	}
+
+	for _, item := range pkg.Manifest.Items {
+		if !strings.Contains(" "+item.Properties+" ", " nav ") {
+			continue
+		}
+		navPath := resolveHref(opfDir, item.Href)
+		f, ok := files[navPath]
+		if !ok {
+			continue
+		}
+		links, err := readNavLinks(f)
+		if err != nil {
+			continue
+		}
+		for _, l := range links {
+			add(path.Dir(navPath), l.href, l.label)
+		}
+		return titles
+	}
+
+	ncxItem, ok := items[pkg.Spine.TOC]
+	if !ok {
+		return titles
+	}
+	ncxPath := resolveHref(opfDir, ncxItem.Href)
+
+	ncx := struct {
+		NavPoints []ncxNavPoint `xml:"navMap>navPoint"`
+	}{}
+	if err := decodeXML(files, ncxPath, &ncx); err != nil {
+		return titles
+	}
+	var walk func(points []ncxNavPoint)
+	walk = func(points []ncxNavPoint) {
+		for _, np := range points {
+			add(path.Dir(ncxPath), np.Content.Src, np.Label)
+			walk(np.Children)
+		}
+	}
+	walk(ncx.NavPoints)
+
+	return titles
+}
+
+type ncxNavPoint struct {
+	Label   string `xml:"navLabel>text"`
+	Content struct {
+		Src string `xml:"src,attr"`
+	} `xml:"content"`
+	Children []ncxNavPoint `xml:"navPoint"`
+}
+
+func decodeXML(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("epub file not found: %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err = xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("decode %s failed: %w", name, err)
+	}
+	return nil
+}
+
+// resolveHref resolves a URL-encoded href relative to a directory of the archive.
+func resolveHref(dir, href string) string {
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	if strings.HasPrefix(href, "/") {
+		return strings.TrimPrefix(href, "/")
+	}
+	return path.Join(dir, href)
+}
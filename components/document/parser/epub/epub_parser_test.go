@@ -0,0 +1,205 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const container = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>`
+
+func xhtml(title, body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>` + title + `</title><style>p { color: red; }</style></head>
+<body>` + body + `</body>
+</html>`
+}
+
+func buildEPUB(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestEPUBParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TestEPUBParser_EPUB3", func(t *testing.T) {
+		data := buildEPUB(t, map[string]string{
+			"mimetype":               "application/epub+zip",
+			"META-INF/container.xml": container,
+			"OEBPS/content.opf": `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="uid">urn:isbn:9780000000000</dc:identifier>
+    <dc:title>The Eino Book</dc:title>
+    <dc:creator>Alice</dc:creator>
+    <dc:creator>Bob</dc:creator>
+    <dc:language>en</dc:language>
+    <dc:subject>AI</dc:subject>
+    <dc:subject>Go</dc:subject>
+    <dc:date>2025-01-01</dc:date>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="cover" href="text/cover.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="text/ch2.xhtml" media-type="application/xhtml+xml"/>
+    <item id="notes" href="text/notes.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine>
+    <itemref idref="cover"/>
+    <itemref idref="ch1"/>
+    <itemref idref="ch2"/>
+    <itemref idref="notes" linear="no"/>
+  </spine>
+</package>`,
+			"OEBPS/nav.xhtml": xhtml("Contents", `<nav epub:type="landmarks"><ol><li><a href="text/ch2.xhtml">Wrong</a></li></ol></nav>
+<nav epub:type="toc"><ol><li><a href="text/chapter%201.xhtml#start">1. Introduction</a></li></ol></nav>`),
+			"OEBPS/text/cover.xhtml":     xhtml("Cover", `<div><img src="cover.jpg" alt="cover"/></div>`),
+			"OEBPS/text/chapter 1.xhtml": xhtml("The Eino Book", `<h1 id="start">Chapter 1</h1><p>Eino is a   framework.</p><p>Line one<br/>Line two</p>`),
+			"OEBPS/text/ch2.xhtml":       xhtml("The Eino Book", `<section><h2>Components</h2><ul><li>ChatModel</li><li>Retriever</li></ul></section>`),
+			"OEBPS/text/notes.xhtml":     xhtml("Notes", `<p>Footnote.</p>`),
+		})
+
+		p, err := NewEPUBParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithURI("book.epub"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+
+		assert.Equal(t, "Chapter 1\nEino is a framework.\nLine one\nLine two", docs[0].Content)
+		assert.Equal(t, "1. Introduction", docs[0].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyChapter])
+		assert.Equal(t, "OEBPS/text/chapter 1.xhtml", docs[0].MetaData[MetaKeyChapterHref])
+		assert.Equal(t, "The Eino Book", docs[0].MetaData[MetaKeyTitle])
+		assert.Equal(t, "Alice, Bob", docs[0].MetaData[MetaKeyAuthor])
+		assert.Equal(t, "en", docs[0].MetaData[MetaKeyLanguage])
+		assert.Equal(t, "urn:isbn:9780000000000", docs[0].MetaData[MetaKeyIdentifier])
+		assert.Equal(t, "2025-01-01", docs[0].MetaData[MetaKeyDate])
+		assert.Equal(t, []string{"AI", "Go"}, docs[0].MetaData[MetaKeySubjects])
+		assert.Equal(t, "book.epub", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+
+		// not in the toc, the title falls back to the first heading.
+		assert.Equal(t, "Components\nChatModel\nRetriever", docs[1].Content)
+		assert.Equal(t, "Components", docs[1].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyChapter])
+
+		assert.Equal(t, "Footnote.", docs[2].Content)
+		assert.Equal(t, "Notes", docs[2].MetaData[MetaKeyChapterTitle])
+
+		p, err = NewEPUBParser(ctx, &Config{SkipNonLinear: true})
+		assert.NoError(t, err)
+		docs, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+	})
+
+	t.Run("TestEPUBParser_SelfClosingTags", func(t *testing.T) {
+		data := buildEPUB(t, map[string]string{
+			"META-INF/container.xml": container,
+			"OEBPS/content.opf": `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <manifest>
+    <item id="ch1" href="ch1.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine>
+    <itemref idref="ch1"/>
+  </spine>
+</package>`,
+			"OEBPS/ch1.xhtml": `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><title/><script src="x.js"/><link rel="stylesheet" href="a.css"/></head>
+<body><h1>Chapter</h1><p>Body<br/>text</p><div/><p>More</p></body>
+</html>`,
+		})
+
+		p, err := NewEPUBParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "Chapter\nBody\ntext\nMore", docs[0].Content)
+		assert.Equal(t, "Chapter", docs[0].MetaData[MetaKeyChapterTitle])
+	})
+
+	t.Run("TestEPUBParser_EPUB2", func(t *testing.T) {
+		data := buildEPUB(t, map[string]string{
+			"META-INF/container.xml": container,
+			"OEBPS/content.opf": `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Old Book</dc:title></metadata>
+  <manifest>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="ch1" href="ch1.html" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="ch2.html" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine toc="ncx"><itemref idref="ch1"/><itemref idref="ch2"/></spine>
+</package>`,
+			"OEBPS/toc.ncx": `<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <navMap>
+    <navPoint id="p1"><navLabel><text>Part One</text></navLabel><content src="ch1.html"/>
+      <navPoint id="p2"><navLabel><text>Nested Chapter</text></navLabel><content src="ch2.html#sec"/></navPoint>
+    </navPoint>
+  </navMap>
+</ncx>`,
+			"OEBPS/ch1.html": xhtml("ch1", `<p>First.</p>`),
+			"OEBPS/ch2.html": xhtml("ch2", `<p>Second.</p>`),
+		})
+
+		p, err := NewEPUBParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "Part One", docs[0].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, "Nested Chapter", docs[1].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, "Old Book", docs[1].MetaData[MetaKeyTitle])
+	})
+
+	t.Run("TestEPUBParser_MissingContainer", func(t *testing.T) {
+		p, err := NewEPUBParser(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader(buildEPUB(t, map[string]string{"mimetype": "application/epub+zip"})))
+		assert.Error(t, err)
+	})
+}
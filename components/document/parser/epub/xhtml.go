@@ -0,0 +1,254 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blockAtoms are the elements that start a new line in the extracted text.
+var blockAtoms = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Dd: true,
+	atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true, atom.Footer: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true, atom.Ol: true,
+	atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true, atom.Tr: true, atom.Ul: true,
+}
+
+type chapter struct {
+	title string
+	text  string
+}
+
+// readChapter extracts the text of a XHTML content document, one line per block,
+// and its title from the first heading, or from <title> if it has no heading.
+func readChapter(f *zip.File) (*chapter, error) {
+	root, err := parseHTML(f)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := &chapter{}
+	var (
+		lines   []string
+		line    strings.Builder
+		heading string
+		title   string
+	)
+	flush := func() {
+		if l := strings.TrimSpace(strings.Join(strings.Fields(line.String()), " ")); l != "" {
+			lines = append(lines, l)
+		}
+		line.Reset()
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			line.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			switch n.DataAtom {
+			case atom.Head:
+				if t := findFirst(n, atom.Title); t != nil {
+					title = textOf(t)
+				}
+				return
+			case atom.Script, atom.Style:
+				return
+			case atom.Br:
+				flush()
+				return
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				if heading == "" {
+					heading = textOf(n)
+				}
+			}
+		}
+
+		block := n.Type == html.ElementNode && blockAtoms[n.DataAtom]
+		if block {
+			flush()
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			flush()
+		}
+	}
+	walk(root)
+	flush()
+
+	ch.text = strings.Join(lines, "\n")
+	ch.title = heading
+	if ch.title == "" {
+		ch.title = title
+	}
+	return ch, nil
+}
+
+type navLink struct {
+	href  string
+	label string
+}
+
+// readNavLinks returns the links of the table of contents of an EPUB 3 navigation document,
+// which is the <nav epub:type="toc">, or the first <nav> if none is typed.
+func readNavLinks(f *zip.File) ([]navLink, error) {
+	root, err := parseHTML(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var navs []*html.Node
+	var find func(n *html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Nav {
+			navs = append(navs, n)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(root)
+	if len(navs) == 0 {
+		return nil, nil
+	}
+
+	toc := navs[0]
+	for _, nav := range navs {
+		for _, attr := range nav.Attr {
+			if attr.Key == "epub:type" || attr.Key == "type" {
+				if strings.Contains(" "+attr.Val+" ", " toc ") {
+					toc = nav
+				}
+			}
+		}
+	}
+
+	var links []navLink
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.A {
+			for _, attr := range n.Attr {
+				if attr.Key == "href" {
+					links = append(links, navLink{href: attr.Val, label: textOf(n)})
+				}
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(toc)
+
+	return links, nil
+}
+
+// voidAtoms are the HTML elements without content, the only ones an HTML parser closes on "/>".
+var voidAtoms = map[atom.Atom]bool{
+	atom.Area: true, atom.Base: true, atom.Br: true, atom.Col: true, atom.Embed: true, atom.Hr: true,
+	atom.Img: true, atom.Input: true, atom.Keygen: true, atom.Link: true, atom.Meta: true, atom.Param: true,
+	atom.Source: true, atom.Track: true, atom.Wbr: true,
+}
+
+// parseHTML parses a XHTML content document with the lenient HTML parser.
+func parseHTML(f *zip.File) (*html.Node, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := closeSelfClosingTags(rc)
+	if err != nil {
+		return nil, err
+	}
+	return html.Parse(bytes.NewReader(data))
+}
+
+// closeSelfClosingTags rewrites the self-closing tags of non-void elements, valid in XHTML, e.g. <title/>
+// or <script src="x"/>, into a start and an end tag, as an HTML parser ignores "/>" and would treat
+// the rest of the document as their content.
+func closeSelfClosingTags(r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if errors.Is(z.Err(), io.EOF) {
+				return buf.Bytes(), nil
+			}
+			return nil, z.Err()
+		}
+		if tt != html.SelfClosingTagToken {
+			buf.Write(z.Raw())
+			continue
+		}
+
+		raw := append([]byte(nil), z.Raw()...)
+		tok := z.Token()
+		if voidAtoms[tok.DataAtom] {
+			buf.Write(raw)
+			continue
+		}
+		// the tokenizer switches to raw text after <title/> or <script/> as well, while the element is closed.
+		z.NextIsNotRawText()
+		tok.Type = html.StartTagToken
+		buf.WriteString(tok.String())
+		tok.Type = html.EndTagToken
+		buf.WriteString(tok.String())
+	}
+}
+
+func findFirst(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findFirst(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func textOf(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -19,6 +19,7 @@ package html
 import (
 	"context"
 	"io"
+	"net/url"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -41,10 +42,24 @@ var _ parser.Parser = (*Parser)(nil)
 type Config struct {
 	// content selector of goquery. eg: body for <body>, #id for <div id="id">
 	Selector *string
+	// ToMarkdown converts the selected content to Markdown instead of plain text,
+	// keeping headings, lists, tables, code blocks and links.
+	// Relative link and image targets are resolved against the <base> of the page or the URI passed by parser.WithURI.
+	ToMarkdown bool
+	// RemoveSelectors are the goquery selectors of the boilerplate elements removed from the selected content
+	// before extracting it, the elements matched by Selector themselves are kept.
+	// If nil, DefaultRemoveSelectors is used, set an empty slice to keep every element.
+	RemoveSelectors []string
 }
 
 var (
 	BodySelector = "body"
+
+	// DefaultRemoveSelectors are the elements that hardly ever hold the main content of a page.
+	DefaultRemoveSelectors = []string{
+		"script", "style", "noscript", "template", "iframe", "svg",
+		"nav", "footer", "aside",
+	}
 )
 
 // NewParser returns a new parser.
@@ -58,8 +73,9 @@ func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
 	}, nil
 }
 
-// Parser implements parser.Parser. It parses HTML content to text.
-// use goquery to parse the HTML content, will read the <body> content as text (remove tags).
+// Parser implements parser.Parser. It parses HTML content to text or Markdown.
+// use goquery to parse the HTML content, will read the <body> content as text (remove tags), or convert it to Markdown.
+// boilerplate elements such as <nav>, <footer> and <script> are removed first.
 // will extract title/description/language/charset from the HTML content as meta data.
 type Parser struct {
 	conf *Config
@@ -73,14 +89,6 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 
 	option := parser.GetCommonOptions(&parser.Options{}, opts...)
 
-	var contentSel *goquery.Selection
-
-	if p.conf.Selector != nil {
-		contentSel = doc.Find(*p.conf.Selector).Contents()
-	} else {
-		contentSel = doc.Contents()
-	}
-
 	meta, err := p.getMetaData(ctx, doc)
 	if err != nil {
 		return nil, err
@@ -93,8 +101,29 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 		}
 	}
 
-	sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
-	content := strings.TrimSpace(sanitized)
+	scope := doc.Selection
+	if p.conf.Selector != nil {
+		scope = doc.Find(*p.conf.Selector)
+	}
+
+	// boilerplate is removed within the selected elements only, so a selector may target e.g. an <aside>.
+	removeSelectors := p.conf.RemoveSelectors
+	if removeSelectors == nil {
+		removeSelectors = DefaultRemoveSelectors
+	}
+	if len(removeSelectors) > 0 {
+		scope.Find(strings.Join(removeSelectors, ", ")).Remove()
+	}
+	contentSel := scope.Contents()
+
+	var content string
+	if p.conf.ToMarkdown {
+		converter := &markdownConverter{base: getBaseURL(doc, option.URI)}
+		content = converter.convert(contentSel.Nodes)
+	} else {
+		sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
+		content = strings.TrimSpace(sanitized)
+	}
 
 	document := &schema.Document{
 		Content:  content,
@@ -139,3 +168,28 @@ func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[st
 
 	return meta, nil
 }
+
+// getBaseURL returns the URL relative references of the page are resolved against,
+// which is the <base href> of the page if any, resolved against the URI of the page.
+func getBaseURL(doc *goquery.Document, uri string) *url.URL {
+	base, err := url.Parse(uri)
+	if err != nil || uri == "" {
+		base = nil
+	}
+
+	href, ok := doc.Find("base[href]").First().Attr("href")
+	if !ok {
+		return base
+	}
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return base
+	}
+	if base == nil {
+		if ref.IsAbs() {
+			return ref
+		}
+		return nil
+	}
+	return base.ResolveReference(ref)
+}
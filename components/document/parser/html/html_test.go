@@ -19,6 +19,7 @@ package html
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/eino/components/document/parser"
@@ -83,4 +84,103 @@ func TestHTMLParser(t *testing.T) {
 		assert.Equal(t, "content in xid", docs[0].Content)
 	})
 
+	t.Run("test boilerplate removed from text", func(t *testing.T) {
+		f, err := os.Open("./testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(docs))
+		assert.Contains(t, docs[0].Content, "Getting   Started")
+		assert.NotContains(t, docs[0].Content, "should not appear")
+		assert.NotContains(t, docs[0].Content, "Home")
+		assert.NotContains(t, docs[0].Content, "Copyright footer")
+	})
+
+	t.Run("test to markdown", func(t *testing.T) {
+		sel := "#content"
+		p, err := NewParser(context.Background(), &Config{
+			Selector:   &sel,
+			ToMarkdown: true,
+		})
+		assert.NoError(t, err)
+
+		f, err := os.Open("./testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(context.Background(), f, parser.WithURI("https://example.com/docs/intro/index.html"))
+		assert.NoError(t, err)
+
+		expected := "# Getting Started\n\n" +
+			"Eino is a **framework** for building *LLM* apps. See the [install guide](https://example.com/docs/guide/install.html) or [GitHub](https://github.com/cloudwego/eino).\n\n" +
+			"## Features\n\n" +
+			"- Components\n  - ChatModel\n  - Retriever\n- Orchestration with `compose.Graph`\n\n" +
+			"3. third\n4. fourth\n\n" +
+			"## Example\n\n" +
+			"```go\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n```\n\n" +
+			"| Name | Type |\n| --- | --- |\n| ark | model \\| embedding |\n| milvus | indexer |\n\n" +
+			"> Quoted text.\n\n" +
+			"![logo](https://example.com/img/logo.png)\nLine two"
+
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, expected, docs[0].Content)
+		assert.Equal(t, "Eino Guide", docs[0].MetaData[MetaKeyTitle])
+	})
+
+	t.Run("test keep boilerplate", func(t *testing.T) {
+		p, err := NewParser(context.Background(), &Config{
+			ToMarkdown:      true,
+			RemoveSelectors: []string{},
+		})
+		assert.NoError(t, err)
+
+		f, err := os.Open("./testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(docs))
+		// relative links are kept as is without a URI.
+		assert.Contains(t, docs[0].Content, "[Home](/) | [Docs](/docs)")
+		assert.Contains(t, docs[0].Content, "Copyright footer")
+		assert.NotContains(t, docs[0].Content, "should not appear")
+		assert.NotContains(t, docs[0].Content, "Eino Guide")
+	})
+
+	t.Run("test form and selected aside kept", func(t *testing.T) {
+		sel := "aside"
+		p, err := NewParser(context.Background(), &Config{
+			Selector: &sel,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(context.Background(), strings.NewReader(
+			`<html><body><aside><form>sidebar content</form><script>x()</script></aside></body></html>`))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "sidebar content", docs[0].Content)
+	})
+
+	t.Run("test colspan clamped", func(t *testing.T) {
+		p, err := NewParser(context.Background(), &Config{
+			ToMarkdown: true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(context.Background(), strings.NewReader(
+			`<table><tr><th colspan="100000000">a</th><th colspan="5000">b</th></tr><tr><td>1</td></tr></table>`))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(docs))
+		lines := strings.Split(docs[0].Content, "\n")
+		assert.Equal(t, 3, len(lines))
+		assert.Equal(t, maxTableColumns, strings.Count(lines[1], "---"))
+		assert.NotContains(t, lines[0], "b")
+	})
 }
@@ -0,0 +1,411 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blockAtoms are the elements rendered as blocks, separated from their siblings by a blank line.
+var blockAtoms = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Body: true,
+	atom.Dd: true, atom.Details: true, atom.Dialog: true, atom.Div: true, atom.Dl: true, atom.Dt: true,
+	atom.Fieldset: true, atom.Figcaption: true, atom.Figure: true, atom.Footer: true, atom.Form: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Header: true, atom.Hgroup: true, atom.Hr: true, atom.Html: true, atom.Li: true, atom.Main: true,
+	atom.Nav: true, atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Summary: true,
+	atom.Table: true, atom.Ul: true,
+}
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// markdownConverter renders a DOM tree as Markdown.
+// Relative link and image targets are resolved against base when it is not nil.
+type markdownConverter struct {
+	base *url.URL
+}
+
+func (c *markdownConverter) convert(nodes []*html.Node) string {
+	var blocks []string
+	var inline strings.Builder
+	flush := func() {
+		if t := normalizeInline(inline.String()); t != "" {
+			blocks = append(blocks, t)
+		}
+		inline.Reset()
+	}
+
+	for _, n := range nodes {
+		if isBlock(n) {
+			flush()
+			if b := c.block(n); b != "" {
+				blocks = append(blocks, b)
+			}
+			continue
+		}
+		inline.WriteString(c.inline(n))
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func (c *markdownConverter) children(n *html.Node) string {
+	var nodes []*html.Node
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		nodes = append(nodes, ch)
+	}
+	return c.convert(nodes)
+}
+
+func (c *markdownConverter) block(n *html.Node) string {
+	if level, ok := headingLevels[n.DataAtom]; ok {
+		text := strings.ReplaceAll(c.inlineChildren(n), "\n", " ")
+		if text = normalizeInline(text); text == "" {
+			return ""
+		}
+		return strings.Repeat("#", level) + " " + text
+	}
+
+	switch n.DataAtom {
+	case atom.Hr:
+		return "---"
+	case atom.Pre:
+		return c.codeBlock(n)
+	case atom.Ul, atom.Ol:
+		return c.list(n)
+	case atom.Table:
+		return c.table(n)
+	case atom.Blockquote:
+		content := c.children(n)
+		if content == "" {
+			return ""
+		}
+		lines := strings.Split(content, "\n")
+		for i, l := range lines {
+			lines[i] = strings.TrimRight("> "+l, " ")
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return c.children(n)
+	}
+}
+
+func (c *markdownConverter) inlineChildren(n *html.Node) string {
+	var sb strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		sb.WriteString(c.inline(ch))
+	}
+	return sb.String()
+}
+
+// inline renders a node as inline content, flattening any block inside it.
+// Whitespace is collapsed later by normalizeInline, explicit line breaks are kept as '\n'.
+func (c *markdownConverter) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseSpaces(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Head, atom.Script, atom.Style, atom.Noscript, atom.Template:
+		return ""
+	case atom.Br:
+		return "\n"
+	case atom.Img:
+		src := c.resolve(getAttr(n, "src"))
+		if src == "" {
+			return ""
+		}
+		return fmt.Sprintf("![%s](%s)", escapeBrackets(getAttr(n, "alt")), src)
+	case atom.A:
+		text := strings.TrimSpace(strings.ReplaceAll(c.inlineChildren(n), "\n", " "))
+		href := getAttr(n, "href")
+		if href == "" || strings.HasPrefix(strings.ToLower(strings.TrimSpace(href)), "javascript:") {
+			return text
+		}
+		href = c.resolve(href)
+		if text == "" {
+			text = href
+		}
+		return fmt.Sprintf("[%s](%s)", text, href)
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		code := collapseSpaces(textContent(n))
+		if strings.TrimSpace(code) == "" {
+			return code
+		}
+		fence := strings.Repeat("`", maxRun(code, '`')+1)
+		if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+			return fence + " " + code + " " + fence
+		}
+		return fence + code + fence
+	case atom.Strong, atom.B:
+		return wrapInline(c.inlineChildren(n), "**")
+	case atom.Em, atom.I:
+		return wrapInline(c.inlineChildren(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(c.inlineChildren(n), "~~")
+	default:
+		content := c.inlineChildren(n)
+		if isBlock(n) {
+			// blocks nested in inline elements still break the line.
+			return "\n" + content + "\n"
+		}
+		return content
+	}
+}
+
+func (c *markdownConverter) codeBlock(n *html.Node) string {
+	code := strings.TrimRight(textContent(n), "\n")
+	if strings.TrimSpace(code) == "" {
+		return ""
+	}
+	code = strings.TrimPrefix(code, "\n")
+
+	lang := codeLanguage(n)
+	for ch := n.FirstChild; ch != nil && lang == ""; ch = ch.NextSibling {
+		if ch.DataAtom == atom.Code {
+			lang = codeLanguage(ch)
+		}
+	}
+
+	fence := strings.Repeat("`", max(3, maxRun(code, '`')+1))
+	return fence + lang + "\n" + code + "\n" + fence
+}
+
+func (c *markdownConverter) list(n *html.Node) string {
+	ordered := n.DataAtom == atom.Ol
+	index := 1
+	if start, err := strconv.Atoi(getAttr(n, "start")); err == nil && ordered {
+		index = start
+	}
+
+	var items []string
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+
+		// keep the list tight, nested lists follow their item text directly.
+		content := strings.ReplaceAll(c.children(li), "\n\n", "\n")
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(content, "\n")
+		for i, l := range lines {
+			switch {
+			case i == 0:
+				lines[i] = marker + l
+			case l != "":
+				lines[i] = indent + l
+			}
+		}
+		items = append(items, strings.TrimRight(strings.Join(lines, "\n"), " "))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+const (
+	// maxColspan is the largest colspan honored by browsers.
+	maxColspan = 1000
+	// maxTableColumns bounds the width of a table row, cells beyond it are dropped.
+	maxTableColumns = 1000
+)
+
+func (c *markdownConverter) table(n *html.Node) string {
+	var rows [][]string
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			switch ch.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				collect(ch)
+			case atom.Tr:
+				var row []string
+				for cell := ch.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.DataAtom != atom.Td && cell.DataAtom != atom.Th {
+						continue
+					}
+					if len(row) >= maxTableColumns {
+						break
+					}
+					text := normalizeInline(strings.ReplaceAll(c.inline(cell), "\n", " "))
+					row = append(row, strings.ReplaceAll(text, "|", "\\|"))
+					// spanned columns are left empty to keep the following cells aligned,
+					// the span is clamped as browsers do.
+					if span, err := strconv.Atoi(getAttr(cell, "colspan")); err == nil {
+						span = min(span, maxColspan, maxTableColumns-len(row)+1)
+						for i := 1; i < span; i++ {
+							row = append(row, "")
+						}
+					}
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	collect(n)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, strings.Repeat("| --- ", width)+"|")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *markdownConverter) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if c.base == nil || ref == "" {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return c.base.ResolveReference(u).String()
+}
+
+func isBlock(n *html.Node) bool {
+	return n.Type == html.ElementNode && blockAtoms[n.DataAtom]
+}
+
+func codeLanguage(n *html.Node) string {
+	for _, class := range strings.Fields(getAttr(n, "class")) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(class, prefix) {
+				return strings.TrimPrefix(class, prefix)
+			}
+		}
+	}
+	return ""
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		if ch.Type == html.ElementNode && ch.DataAtom == atom.Br {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(textContent(ch))
+	}
+	return sb.String()
+}
+
+// collapseSpaces replaces every run of whitespace with a single space, as browsers do.
+func collapseSpaces(s string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' {
+			if !space {
+				sb.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		sb.WriteRune(r)
+		space = false
+	}
+	return sb.String()
+}
+
+// normalizeInline trims the lines of collapsed inline content and drops empty ones.
+func normalizeInline(s string) string {
+	lines := strings.Split(s, "\n")
+	ret := lines[:0]
+	for _, l := range lines {
+		if l = strings.TrimSpace(collapseSpaces(l)); l != "" {
+			ret = append(ret, l)
+		}
+	}
+	return strings.Join(ret, "\n")
+}
+
+// wrapInline surrounds the content with the emphasis marker, keeping the surrounding spaces outside of it.
+func wrapInline(content, marker string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return content
+	}
+	lead := content[:strings.Index(content, trimmed)]
+	trail := content[len(lead)+len(trimmed):]
+	return lead + marker + trimmed + marker + trail
+}
+
+func escapeBrackets(s string) string {
+	return strings.NewReplacer("[", "\\[", "]", "\\]").Replace(s)
+}
+
+func maxRun(s string, c rune) int {
+	longest, cur := 0, 0
+	for _, r := range s {
+		if r == c {
+			cur++
+			longest = max(longest, cur)
+		} else {
+			cur = 0
+		}
+	}
+	return longest
+}
@@ -0,0 +1,324 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MetaKeySource        = "_source"
+	MetaKeySection       = "_section"
+	MetaKeySectionIndex  = "_section_index"
+	MetaKeyCodeLanguages = "_code_languages"
+)
+
+var _ parser.Parser = (*Parser)(nil)
+
+type Config struct {
+	// ToSections emits one document per top-level section instead of a single document.
+	// Each section document starts with its heading line, so it can be further split by splitter/markdown.
+	// Content before the first heading is emitted as a section of its own, without MetaKeySection.
+	ToSections bool
+	// SectionLevel is the ATX heading level (1 for '#', 2 for '##' ...) that starts a new section.
+	// Defaults to the highest heading level present in the document.
+	SectionLevel int
+	// FrontMatterKey, if set, stores the parsed front-matter as a map under this metadata key,
+	// instead of merging its fields into the top level of the metadata.
+	FrontMatterKey string
+}
+
+// NewParser returns a new markdown parser.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+	if conf.SectionLevel < 0 || conf.SectionLevel > 6 {
+		return nil, fmt.Errorf("section level should be between 1 and 6, got %d", conf.SectionLevel)
+	}
+
+	return &Parser{
+		conf: conf,
+	}, nil
+}
+
+// Parser implements parser.Parser. It parses markdown content to documents.
+// YAML (delimited by ---) and TOML (delimited by +++) front-matter is removed from the content and parsed into metadata.
+// Headings inside fenced code blocks never start a section, and code blocks are kept verbatim,
+// with the languages of their info strings listed under MetaKeyCodeLanguages.
+type Parser struct {
+	conf *Config
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("markdown parser read all from reader failed: %w", err)
+	}
+
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+	specificOpts := parser.GetImplSpecificOptions(&options{
+		toSections: &p.conf.ToSections,
+	}, opts...)
+
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	frontMatter, body, err := parseFrontMatter(text)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := make(map[string]any, len(frontMatter)+len(option.ExtraMeta)+1)
+	if p.conf.FrontMatterKey != "" {
+		if frontMatter != nil {
+			meta[p.conf.FrontMatterKey] = frontMatter
+		}
+	} else {
+		for k, v := range frontMatter {
+			meta[k] = v
+		}
+	}
+	meta[MetaKeySource] = option.URI
+	for k, v := range option.ExtraMeta {
+		meta[k] = v
+	}
+
+	lines := scanLines(body)
+
+	if specificOpts.toSections == nil || !*specificOpts.toSections {
+		return []*schema.Document{newDocument(lines, meta)}, nil
+	}
+
+	var docs []*schema.Document
+	for _, sec := range splitSections(lines, p.conf.SectionLevel) {
+		doc := newDocument(sec.lines, meta)
+		if strings.TrimSpace(doc.Content) == "" {
+			continue
+		}
+		if sec.title != nil {
+			doc.MetaData[MetaKeySection] = *sec.title
+		}
+		doc.MetaData[MetaKeySectionIndex] = len(docs)
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func newDocument(lines []mdLine, meta map[string]any) *schema.Document {
+	docMeta := make(map[string]any, len(meta)+3)
+	for k, v := range meta {
+		docMeta[k] = v
+	}
+
+	var (
+		texts []string
+		langs []string
+		seen  = map[string]bool{}
+	)
+	for _, l := range lines {
+		texts = append(texts, l.text)
+		if l.codeLang != "" && !seen[l.codeLang] {
+			seen[l.codeLang] = true
+			langs = append(langs, l.codeLang)
+		}
+	}
+	if len(langs) > 0 {
+		docMeta[MetaKeyCodeLanguages] = langs
+	}
+
+	return &schema.Document{
+		// only trim blank lines, leading indentation is meaningful in markdown.
+		Content:  strings.Trim(strings.Join(texts, "\n"), "\n"),
+		MetaData: docMeta,
+	}
+}
+
+// parseFrontMatter splits the leading front-matter block from the text.
+// A text without a complete front-matter block, or whose YAML block is not a mapping, is returned unchanged as the body.
+func parseFrontMatter(text string) (map[string]any, string, error) {
+	firstLine, rest, found := strings.Cut(text, "\n")
+	if !found {
+		return nil, text, nil
+	}
+
+	var closing []string
+	switch strings.TrimRight(firstLine, " \t") {
+	case "---":
+		closing = []string{"---", "..."}
+	case "+++":
+		closing = []string{"+++"}
+	default:
+		return nil, text, nil
+	}
+
+	var (
+		raw  strings.Builder
+		body string
+		done bool
+	)
+	for !done && rest != "" {
+		var line string
+		line, rest, _ = strings.Cut(rest, "\n")
+		for _, c := range closing {
+			if strings.TrimRight(line, " \t") == c {
+				body, done = rest, true
+				break
+			}
+		}
+		if !done {
+			raw.WriteString(line)
+			raw.WriteString("\n")
+		}
+	}
+	if !done {
+		return nil, text, nil
+	}
+
+	fm := make(map[string]any)
+	if closing[0] == "+++" {
+		if err := toml.Unmarshal([]byte(raw.String()), &fm); err != nil {
+			return nil, "", fmt.Errorf("markdown parser parse front-matter failed: %w", err)
+		}
+		return fm, body, nil
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(raw.String()), &node); err != nil {
+		return nil, "", fmt.Errorf("markdown parser parse front-matter failed: %w", err)
+	}
+	if len(node.Content) == 0 {
+		return fm, body, nil
+	}
+	// a block that is not a mapping is more likely enclosed by thematic breaks than a front-matter.
+	if node.Content[0].Kind != yaml.MappingNode {
+		return nil, text, nil
+	}
+	if err := node.Content[0].Decode(&fm); err != nil {
+		return nil, "", fmt.Errorf("markdown parser parse front-matter failed: %w", err)
+	}
+
+	return fm, body, nil
+}
+
+type mdLine struct {
+	text string
+	// headingLevel is the level of an ATX heading outside of code blocks, 0 for other lines.
+	headingLevel int
+	heading      string
+	// codeLang is the language tag of an opening code fence, empty for other lines.
+	codeLang string
+}
+
+// scanLines classifies the lines of a markdown body, following the CommonMark rules for ATX headings and code fences.
+func scanLines(body string) []mdLine {
+	var (
+		ret       []mdLine
+		fenceChar byte
+		fenceLen  int
+	)
+	for _, text := range strings.Split(body, "\n") {
+		l := mdLine{text: text}
+
+		trimmed := strings.TrimLeft(text, " ")
+		indented := len(text)-len(trimmed) > 3
+
+		if fenceLen > 0 {
+			// a closing fence uses the same character, is at least as long and has no info string.
+			if !indented && countPrefix(trimmed, fenceChar) >= fenceLen && strings.TrimSpace(strings.TrimLeft(trimmed, string(fenceChar))) == "" {
+				fenceLen = 0
+			}
+			ret = append(ret, l)
+			continue
+		}
+
+		if !indented && len(trimmed) > 0 && (trimmed[0] == '`' || trimmed[0] == '~') {
+			if n := countPrefix(trimmed, trimmed[0]); n >= 3 {
+				info := strings.TrimSpace(trimmed[n:])
+				if trimmed[0] != '`' || !strings.Contains(info, "`") {
+					fenceChar, fenceLen = trimmed[0], n
+					if fields := strings.Fields(info); len(fields) > 0 {
+						l.codeLang = fields[0]
+					}
+					ret = append(ret, l)
+					continue
+				}
+			}
+		}
+
+		if !indented {
+			if n := countPrefix(trimmed, '#'); n >= 1 && n <= 6 && (len(trimmed) == n || trimmed[n] == ' ' || trimmed[n] == '\t') {
+				l.headingLevel = n
+				l.heading = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(trimmed[n:]), "#"))
+			}
+		}
+
+		ret = append(ret, l)
+	}
+	return ret
+}
+
+type section struct {
+	title *string
+	lines []mdLine
+}
+
+func splitSections(lines []mdLine, level int) []section {
+	if level == 0 {
+		for _, l := range lines {
+			if l.headingLevel > 0 && (level == 0 || l.headingLevel < level) {
+				level = l.headingLevel
+			}
+		}
+	}
+
+	var (
+		ret []section
+		cur = section{}
+	)
+	for _, l := range lines {
+		if l.headingLevel > 0 && l.headingLevel <= level {
+			if len(cur.lines) > 0 {
+				ret = append(ret, cur)
+			}
+			title := l.heading
+			cur = section{title: &title}
+		}
+		cur.lines = append(cur.lines, l)
+	}
+	if len(cur.lines) > 0 {
+		ret = append(ret, cur)
+	}
+	return ret
+}
+
+func countPrefix(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownParser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("test whole document", func(t *testing.T) {
+		f, err := os.Open("testdata/frontmatter.md")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("testdata/frontmatter.md"), parser.WithExtraMeta(map[string]any{"key": "value"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.True(t, strings.HasPrefix(docs[0].Content, "Intro paragraph."))
+		assert.Contains(t, docs[0].Content, "```go\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n```")
+		assert.Equal(t, "Getting Started", docs[0].MetaData["title"])
+		assert.Equal(t, []any{"eino", "rag"}, docs[0].MetaData["tags"])
+		assert.Equal(t, false, docs[0].MetaData["draft"])
+		assert.Equal(t, "testdata/frontmatter.md", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "value", docs[0].MetaData["key"])
+		assert.Equal(t, []string{"bash", "go"}, docs[0].MetaData[MetaKeyCodeLanguages])
+	})
+
+	t.Run("test to sections", func(t *testing.T) {
+		f, err := os.Open("testdata/frontmatter.md")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewParser(ctx, &Config{FrontMatterKey: "front_matter"})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, WithToSections(true))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+
+		assert.Equal(t, "Intro paragraph.", docs[0].Content)
+		_, ok := docs[0].MetaData[MetaKeySection]
+		assert.False(t, ok)
+
+		// the comment in the code block does not start a new section, and ## stays in its parent section.
+		assert.Equal(t, "Install", docs[1].MetaData[MetaKeySection])
+		assert.Equal(t, 1, docs[1].MetaData[MetaKeySectionIndex])
+		assert.True(t, strings.HasPrefix(docs[1].Content, "# Install\n"))
+		assert.Contains(t, docs[1].Content, "# not a heading")
+		assert.Contains(t, docs[1].Content, "## Requirements")
+		assert.Equal(t, []string{"bash"}, docs[1].MetaData[MetaKeyCodeLanguages])
+
+		assert.Equal(t, "Usage", docs[2].MetaData[MetaKeySection])
+		assert.Equal(t, []string{"go"}, docs[2].MetaData[MetaKeyCodeLanguages])
+
+		for _, doc := range docs {
+			fm, ok := doc.MetaData["front_matter"].(map[string]any)
+			assert.True(t, ok)
+			assert.Equal(t, "Getting Started", fm["title"])
+			_, ok = doc.MetaData["title"]
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("test toml front-matter and section level", func(t *testing.T) {
+		f, err := os.Open("testdata/toml.md")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewParser(ctx, &Config{ToSections: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "## Options\n\nSome options.", docs[0].Content)
+		assert.Equal(t, "Options", docs[0].MetaData[MetaKeySection])
+		assert.Equal(t, "Config", docs[0].MetaData["title"])
+		assert.Equal(t, int64(3), docs[0].MetaData["weight"])
+	})
+
+	t.Run("test without front-matter", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("---\nnot closed\n\n~~~\n# code\n~~~"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "---\nnot closed\n\n~~~\n# code\n~~~", docs[0].Content)
+	})
+
+	t.Run("test thematic breaks without front-matter", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		text := "---\nSome introduction.\n\n---\n\n# Title\n\nbody"
+		docs, err := p.Parse(ctx, strings.NewReader(text))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, text, docs[0].Content)
+		assert.Equal(t, 1, len(docs[0].MetaData))
+	})
+
+	t.Run("test invalid front-matter", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("---\ntitle: [\n---\nbody"))
+		assert.Error(t, err)
+	})
+
+	t.Run("test invalid section level", func(t *testing.T) {
+		_, err := NewParser(ctx, &Config{SectionLevel: 7})
+		assert.Error(t, err)
+	})
+}
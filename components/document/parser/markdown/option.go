@@ -0,0 +1,30 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import "github.com/cloudwego/eino/components/document/parser"
+
+type options struct {
+	toSections *bool
+}
+
+// WithToSections is a parser option that specifies whether to emit one document per top-level section.
+func WithToSections(toSections bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.toSections = &toSections
+	})
+}
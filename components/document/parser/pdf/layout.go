@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dslipak/pdf"
+)
+
+// The thresholds below are expressed as ratios of the font size of the glyphs involved.
+const (
+	// glyphs whose baselines differ by less than this belong to the same line.
+	sameLineRatio = 0.5
+	// a horizontal gap wider than this between two glyphs is a word break.
+	wordGapRatio = 0.2
+	// a horizontal gap wider than this between two glyphs separates two cells of a line.
+	cellGapRatio = 1.5
+	// a vertical gap wider than this between two lines starts a new paragraph.
+	paragraphGapRatio = 1.8
+
+	// defaultFontSize is used for glyphs that do not report a usable font size.
+	defaultFontSize = 10.0
+	// minTableRows is the number of consecutive aligned lines needed to render them as a table,
+	// fewer lines are more likely a heading and a line of text laid out side by side.
+	minTableRows = 3
+)
+
+type textSpan struct {
+	x0, x1 float64
+	sb     strings.Builder
+}
+
+func (s *textSpan) String() string {
+	return strings.TrimSpace(s.sb.String())
+}
+
+type textLine struct {
+	y      float64
+	size   float64
+	glyphs []pdf.Text
+	spans  []*textSpan
+}
+
+// getLayoutText extracts the text of the page ordered by glyph position.
+func getLayoutText(p pdf.Page) (text string, err error) {
+	// the content stream interpreter panics on malformed input.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("extract pdf page content panic: %v", r)
+		}
+	}()
+
+	return renderLayout(p.Content().Text), nil
+}
+
+// renderLayout arranges glyphs top to bottom and left to right, separating paragraphs by a blank line.
+// Runs of at least minTableRows consecutive lines whose cells are vertically aligned are rendered
+// as Markdown tables, using the first line as the table header.
+func renderLayout(glyphs []pdf.Text) string {
+	lines := groupLines(glyphs)
+
+	var out []string
+	for i := 0; i < len(lines); {
+		if i > 0 && lines[i-1].y-lines[i].y > paragraphGapRatio*math.Max(lines[i-1].size, lines[i].size) {
+			out = append(out, "")
+		}
+
+		if j := i + tableRows(lines[i:]); j-i >= minTableRows {
+			out = append(out, "")
+			out = append(out, renderTable(lines[i:j])...)
+			out = append(out, "")
+			i = j
+			continue
+		}
+
+		cells := make([]string, 0, len(lines[i].spans))
+		for _, s := range lines[i].spans {
+			cells = append(cells, s.String())
+		}
+		out = append(out, strings.Join(cells, " "))
+		i++
+	}
+
+	// collapse the blank lines introduced around tables and paragraphs.
+	var sb strings.Builder
+	blank := true
+	for _, l := range out {
+		if l == "" {
+			if !blank {
+				sb.WriteString("\n")
+			}
+			blank = true
+			continue
+		}
+		sb.WriteString(l)
+		sb.WriteString("\n")
+		blank = false
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// groupLines clusters glyphs by baseline, then merges the glyphs of every line into spans.
+func groupLines(glyphs []pdf.Text) []*textLine {
+	sorted := make([]pdf.Text, 0, len(glyphs))
+	for _, g := range glyphs {
+		if strings.TrimSpace(g.S) == "" {
+			continue
+		}
+		sorted = append(sorted, g)
+	}
+
+	// pdf coordinates grow upwards, so the top of the page comes first with a descending Y.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Y != sorted[j].Y {
+			return sorted[i].Y > sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+
+	var (
+		lines []*textLine
+		cur   *textLine
+	)
+	for _, g := range sorted {
+		size := fontSize(g)
+		if cur != nil && math.Abs(cur.y-g.Y) <= sameLineRatio*math.Max(cur.size, size) {
+			cur.glyphs = append(cur.glyphs, g)
+			cur.size = math.Max(cur.size, size)
+			continue
+		}
+		cur = &textLine{y: g.Y, size: size, glyphs: []pdf.Text{g}}
+		lines = append(lines, cur)
+	}
+
+	for _, l := range lines {
+		sort.SliceStable(l.glyphs, func(i, j int) bool {
+			return l.glyphs[i].X < l.glyphs[j].X
+		})
+		l.spans = buildSpans(l.glyphs)
+	}
+
+	return lines
+}
+
+func buildSpans(glyphs []pdf.Text) []*textSpan {
+	var (
+		spans []*textSpan
+		cur   *textSpan
+	)
+	for _, g := range glyphs {
+		size := fontSize(g)
+		if cur != nil {
+			gap := g.X - cur.x1
+			if gap > cellGapRatio*size {
+				cur = nil
+			} else if gap > wordGapRatio*size {
+				cur.sb.WriteByte(' ')
+			}
+		}
+		if cur == nil {
+			cur = &textSpan{x0: g.X}
+			spans = append(spans, cur)
+		}
+
+		cur.sb.WriteString(g.S)
+
+		width := g.W
+		if width <= 0 {
+			// estimate the advance when the font does not report glyph widths.
+			width = 0.5 * size * float64(utf8.RuneCountInString(g.S))
+		}
+		cur.x1 = math.Max(cur.x1, g.X+width)
+	}
+	return spans
+}
+
+// tableRows returns the number of leading lines forming a table: every line has the cell count of the first one,
+// its cells overlap those of the previous line, and all lines share a blank gutter between every two columns.
+func tableRows(lines []*textLine) int {
+	first := lines[0]
+	if len(first.spans) < 2 {
+		return 1
+	}
+
+	// gutters[k] is the horizontal range left blank by all rows so far between the columns k and k+1.
+	gutters := make([][2]float64, len(first.spans)-1)
+	for k := range gutters {
+		gutters[k] = [2]float64{first.spans[k].x1, first.spans[k+1].x0}
+	}
+
+	n := 1
+	for ; n < len(lines) && isAligned(lines[n-1], lines[n]); n++ {
+		next := make([][2]float64, len(gutters))
+		for k, g := range gutters {
+			next[k] = [2]float64{math.Max(g[0], lines[n].spans[k].x1), math.Min(g[1], lines[n].spans[k+1].x0)}
+			if next[k][0] >= next[k][1] {
+				return n
+			}
+		}
+		gutters = next
+	}
+	return n
+}
+
+// isAligned reports whether two lines have the same number of cells (at least two),
+// and every cell horizontally overlaps the cell of the same column in the other line.
+func isAligned(a, b *textLine) bool {
+	if len(a.spans) < 2 || len(a.spans) != len(b.spans) {
+		return false
+	}
+	for i := range a.spans {
+		if a.spans[i].x1 < b.spans[i].x0 || b.spans[i].x1 < a.spans[i].x0 {
+			return false
+		}
+	}
+	return true
+}
+
+func renderTable(lines []*textLine) []string {
+	rows := make([]string, 0, len(lines)+1)
+	for i, l := range lines {
+		cells := make([]string, 0, len(l.spans))
+		for _, s := range l.spans {
+			cells = append(cells, strings.ReplaceAll(s.String(), "|", "\\|"))
+		}
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			sep := make([]string, len(cells))
+			for j := range sep {
+				sep[j] = "---"
+			}
+			rows = append(rows, "| "+strings.Join(sep, " | ")+" |")
+		}
+	}
+	return rows
+}
+
+func fontSize(g pdf.Text) float64 {
+	if g.FontSize > 0 {
+		return g.FontSize
+	}
+	return defaultFontSize
+}
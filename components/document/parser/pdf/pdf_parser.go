@@ -21,22 +21,69 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/dslipak/pdf"
 )
 
+const (
+	// MetaKeyPage is the 1-based page number of a page document, set only with ToPages.
+	MetaKeyPage = "_page"
+	// MetaKeyTotalPages is the number of pages of the PDF file.
+	MetaKeyTotalPages = "_total_pages"
+	// MetaKeyTitle is the Title entry of the document information dictionary.
+	MetaKeyTitle = "_title"
+	// MetaKeyAuthor is the Author entry of the document information dictionary.
+	MetaKeyAuthor = "_author"
+	// MetaKeySubject is the Subject entry of the document information dictionary.
+	MetaKeySubject = "_subject"
+	// MetaKeyKeywords is the Keywords entry of the document information dictionary, as a single string.
+	MetaKeyKeywords = "_keywords"
+	// MetaKeyCreator is the name of the application which created the original document.
+	MetaKeyCreator = "_creator"
+	// MetaKeyProducer is the name of the application which converted the document to PDF.
+	MetaKeyProducer = "_producer"
+	// MetaKeyCreationDate is the creation date of the document in RFC 3339 format,
+	// or the raw entry if it is not a valid PDF date.
+	MetaKeyCreationDate = "_creation_date"
+	// MetaKeyModDate is the last modification date of the document, formatted as MetaKeyCreationDate.
+	MetaKeyModDate = "_mod_date"
+)
+
+// infoMetaKeys maps the entries of the PDF document information dictionary to metadata keys.
+var infoMetaKeys = []struct {
+	entry  string
+	key    string
+	isDate bool
+}{
+	{entry: "Title", key: MetaKeyTitle},
+	{entry: "Author", key: MetaKeyAuthor},
+	{entry: "Subject", key: MetaKeySubject},
+	{entry: "Keywords", key: MetaKeyKeywords},
+	{entry: "Creator", key: MetaKeyCreator},
+	{entry: "Producer", key: MetaKeyProducer},
+	{entry: "CreationDate", key: MetaKeyCreationDate, isDate: true},
+	{entry: "ModDate", key: MetaKeyModDate, isDate: true},
+}
+
 // Config is the configuration for PDF parser.
 type Config struct {
-	ToPages bool // whether to
+	ToPages bool // whether to emit one document per page
+	// Layout reconstructs the reading order of each page from the positions of its glyphs,
+	// instead of relying on the order of the content stream.
+	// Rows of text aligned into columns are detected as tables and rendered as Markdown.
+	Layout bool
 }
 
 // PDFParser reads from io.Reader and parse its content as plain text.
 // Attention: This is in alpha stage, and may not support all PDF use cases well enough.
-// For example, it will not preserve whitespace and new line for now.
+// For example, it will not preserve whitespace and new line for now, unless Layout is enabled.
 type PDFParser struct {
 	ToPages bool
+	Layout  bool
 }
 
 // NewPDFParser creates a new PDF parser.
@@ -44,15 +91,18 @@ func NewPDFParser(ctx context.Context, config *Config) (*PDFParser, error) {
 	if config == nil {
 		config = &Config{}
 	}
-	return &PDFParser{ToPages: config.ToPages}, nil
+	return &PDFParser{ToPages: config.ToPages, Layout: config.Layout}, nil
 }
 
 // Parse parses the PDF content from io.Reader.
+// Every document carries the total page count and the fields of the PDF information dictionary in its metadata,
+// and page documents additionally carry their 1-based page number under MetaKeyPage.
 func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
 	commonOpts := parser.GetCommonOptions(nil, opts...)
 
 	specificOpts := parser.GetImplSpecificOptions(&options{
 		toPages: &pp.ToPages,
+		layout:  &pp.Layout,
 	}, opts...)
 
 	data, err := io.ReadAll(reader)
@@ -71,25 +121,48 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 	var (
 		buf     bytes.Buffer
 		toPages = specificOpts.toPages != nil && *specificOpts.toPages
+		layout  = specificOpts.layout != nil && *specificOpts.layout
 	)
+
+	baseMeta := make(map[string]any, len(commonOpts.ExtraMeta)+len(infoMetaKeys)+1)
+	for k, v := range commonOpts.ExtraMeta {
+		baseMeta[k] = v
+	}
+	for k, v := range getInfoMeta(f) {
+		baseMeta[k] = v
+	}
+	baseMeta[MetaKeyTotalPages] = pages
+
 	fonts := make(map[string]*pdf.Font)
 	for i := 1; i <= pages; i++ {
 		p := f.Page(i)
-		for _, name := range p.Fonts() { // cache fonts so we don't continually parse charmap
-			if _, ok := fonts[name]; !ok {
-				font := p.Font(name)
-				fonts[name] = &font
+
+		var text string
+		if layout {
+			text, err = getLayoutText(p)
+		} else {
+			for _, name := range p.Fonts() { // cache fonts so we don't continually parse charmap
+				if _, ok := fonts[name]; !ok {
+					font := p.Font(name)
+					fonts[name] = &font
+				}
 			}
+			text, err = p.GetPlainText(fonts)
 		}
-		text, err := p.GetPlainText(fonts)
 		if err != nil {
 			return nil, fmt.Errorf("read pdf page failed: %w, page= %d", err, i)
 		}
 
 		if toPages {
+			meta := make(map[string]any, len(baseMeta)+1)
+			for k, v := range baseMeta {
+				meta[k] = v
+			}
+			meta[MetaKeyPage] = i
+
 			docs = append(docs, &schema.Document{
 				Content:  text,
-				MetaData: commonOpts.ExtraMeta,
+				MetaData: meta,
 			})
 		} else {
 			buf.WriteString(text + "\n")
@@ -99,9 +172,72 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 	if !toPages {
 		docs = append(docs, &schema.Document{
 			Content:  buf.String(),
-			MetaData: commonOpts.ExtraMeta,
+			MetaData: baseMeta,
 		})
 	}
 
 	return docs, nil
 }
+
+// getInfoMeta reads the document information dictionary referenced by the trailer.
+// Dates are converted to RFC 3339 when they follow the PDF date format, and kept verbatim otherwise.
+func getInfoMeta(f *pdf.Reader) map[string]any {
+	meta := make(map[string]any)
+
+	info := f.Trailer().Key("Info")
+	if info.IsNull() {
+		return meta
+	}
+
+	for _, item := range infoMetaKeys {
+		val := strings.TrimSpace(info.Key(item.entry).Text())
+		if val == "" {
+			continue
+		}
+		if item.isDate {
+			if t, ok := parseDate(val); ok {
+				val = t.Format(time.RFC3339)
+			}
+		}
+		meta[item.key] = val
+	}
+
+	return meta
+}
+
+// parseDate parses a date in the format defined by PDF 32000-1 7.9.4, e.g. D:20240102150405+08'00'.
+// Every field after the year is optional.
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimPrefix(s, "D:")
+	s = strings.ReplaceAll(s, "'", "")
+
+	digits := 0
+	for digits < len(s) && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits < 4 || digits > 14 || digits%2 != 0 {
+		return time.Time{}, false
+	}
+
+	// pad the omitted fields with their defaults: month and day default to 01, the rest to 00.
+	stamp := s[:digits] + "0101000000"[digits-4:]
+
+	zone := s[digits:]
+	switch {
+	case zone == "" || zone == "Z":
+		zone = "Z"
+	case len(zone) == 3:
+		zone += "00"
+	case len(zone) != 5:
+		return time.Time{}, false
+	}
+	if zone != "Z" {
+		zone = zone[:3] + ":" + zone[3:]
+	}
+
+	t, err := time.Parse("20060102150405Z07:00", stamp+zone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
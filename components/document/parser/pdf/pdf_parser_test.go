@@ -20,8 +20,10 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/dslipak/pdf"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,8 +41,111 @@ func TestLoader_Load(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(docs))
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData)
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPage])
+		assert.Equal(t, 2, docs[0].MetaData[MetaKeyTotalPages])
+		assert.True(t, len(docs[1].Content) > 0)
+		assert.Equal(t, "test", docs[1].MetaData["test"])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPage])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyTotalPages])
+	})
+
+	t.Run("TestLoader_LoadWhole", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./testdata/test_pdf.pdf")
+		assert.NoError(t, err)
+
+		p, err := NewPDFParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		assert.Equal(t, 2, docs[0].MetaData[MetaKeyTotalPages])
+		_, ok := docs[0].MetaData[MetaKeyPage]
+		assert.False(t, ok)
+	})
+
+	t.Run("TestLoader_LoadLayout", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./testdata/test_pdf.pdf")
+		assert.NoError(t, err)
+
+		p, err := NewPDFParser(ctx, &Config{Layout: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, WithToPages(true))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[1].MetaData)
+		assert.True(t, len(docs[1].Content) > 0)
 	})
 }
+
+func TestRenderLayout(t *testing.T) {
+	word := func(s string, x, y float64) []pdf.Text {
+		var ret []pdf.Text
+		for _, r := range s {
+			ret = append(ret, pdf.Text{S: string(r), X: x, Y: y, W: 5, FontSize: 10})
+			x += 5
+		}
+		return ret
+	}
+
+	var glyphs []pdf.Text
+	// glyphs are deliberately out of order, as content streams do not guarantee reading order.
+	glyphs = append(glyphs, word("world", 40, 700)...)
+	glyphs = append(glyphs, word("hello", 10, 700)...)
+	glyphs = append(glyphs, word("next", 10, 688)...)
+	glyphs = append(glyphs, word("name", 10, 600)...)
+	glyphs = append(glyphs, word("age", 100, 600)...)
+	glyphs = append(glyphs, word("bob", 10, 588)...)
+	glyphs = append(glyphs, word("42", 100, 588)...)
+	glyphs = append(glyphs, word("amy", 10, 576)...)
+	glyphs = append(glyphs, word("7", 100, 576)...)
+	glyphs = append(glyphs, word("end", 10, 500)...)
+
+	assert.Equal(t, "hello world\nnext\n\n| name | age |\n| --- | --- |\n| bob | 42 |\n| amy | 7 |\n\nend", renderLayout(glyphs))
+
+	// two aligned lines are not enough for a table
+	glyphs = nil
+	glyphs = append(glyphs, word("Chapter", 10, 700)...)
+	glyphs = append(glyphs, word("1", 100, 700)...)
+	glyphs = append(glyphs, word("Intro", 10, 688)...)
+	glyphs = append(glyphs, word("3", 100, 688)...)
+	assert.Equal(t, "Chapter 1\nIntro 3", renderLayout(glyphs))
+
+	// cells overlapping line by line, but without a gutter shared by all lines
+	glyphs = nil
+	glyphs = append(glyphs, word("aaaaa", 10, 700)...)
+	glyphs = append(glyphs, word("b", 60, 700)...)
+	glyphs = append(glyphs, word("c", 10, 688)...)
+	glyphs = append(glyphs, word("dddddddd", 31, 688)...)
+	glyphs = append(glyphs, word("e", 10, 676)...)
+	glyphs = append(glyphs, word("f", 60, 676)...)
+	assert.Equal(t, "aaaaa b\nc dddddddd\ne f", renderLayout(glyphs))
+}
+
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{in: "D:20240102150405+08'00'", want: "2024-01-02T15:04:05+08:00", ok: true},
+		{in: "D:20240102150405Z", want: "2024-01-02T15:04:05Z", ok: true},
+		{in: "D:2024", want: "2024-01-01T00:00:00Z", ok: true},
+		{in: "20240102", want: "2024-01-02T00:00:00Z", ok: true},
+		{in: "yesterday", ok: false},
+	}
+	for _, c := range cases {
+		got, ok := parseDate(c.in)
+		assert.Equal(t, c.ok, ok, c.in)
+		if ok {
+			assert.Equal(t, c.want, got.Format(time.RFC3339), c.in)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySlide       = "_slide"
+	MetaKeyTotalSlides = "_total_slides"
+	MetaKeySlideTitle  = "_slide_title"
+	MetaKeyNotes       = "_notes"
+	MetaKeySource      = "_source"
+)
+
+const (
+	presentationPart = "ppt/presentation.xml"
+	notesSlideRel    = "/notesSlide"
+)
+
+var _ parser.Parser = (*PPTXParser)(nil)
+
+// Config is the configuration for PPTX parser.
+type Config struct {
+	// IncludeNotes appends the speaker notes to the content of each slide, after the slide text.
+	// The notes are always available in metadata under MetaKeyNotes.
+	IncludeNotes bool
+	// SkipHidden skips the slides hidden from the slide show.
+	SkipHidden bool
+}
+
+// PPTXParser reads from io.Reader and parses PowerPoint (pptx) content, producing one document per slide.
+// The slide title and speaker notes are stored in metadata, and tables are rendered as Markdown.
+type PPTXParser struct {
+	conf *Config
+}
+
+// NewPPTXParser creates a new PPTX parser.
+func NewPPTXParser(ctx context.Context, config *Config) (*PPTXParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	return &PPTXParser{conf: config}, nil
+}
+
+// Parse parses the PPTX content from io.Reader.
+func (pp *PPTXParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("pptx parser read all from reader failed: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open pptx archive failed: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	slideParts, err := listSlides(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+	for i, part := range slideParts {
+		slide, err := readSlide(files, part)
+		if err != nil {
+			return nil, fmt.Errorf("read pptx slide failed: %w, slide= %d", err, i+1)
+		}
+		if slide.hidden && pp.conf.SkipHidden {
+			continue
+		}
+
+		meta := make(map[string]any, len(option.ExtraMeta)+5)
+		meta[MetaKeySource] = option.URI
+		for k, v := range option.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeySlide] = i + 1
+		meta[MetaKeyTotalSlides] = len(slideParts)
+		if slide.title != "" {
+			meta[MetaKeySlideTitle] = slide.title
+		}
+		if slide.notes != "" {
+			meta[MetaKeyNotes] = slide.notes
+		}
+
+		content := slide.text
+		if pp.conf.IncludeNotes && slide.notes != "" {
+			content = strings.TrimSpace(content + "\n\nNotes:\n" + slide.notes)
+		}
+
+		docs = append(docs, &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+// listSlides returns the slide parts in presentation order,
+// falling back to the order of their file names if the presentation part cannot be read.
+func listSlides(files map[string]*zip.File) ([]string, error) {
+	if _, ok := files[presentationPart]; ok {
+		rels, err := readRels(files, presentationPart)
+		if err != nil {
+			return nil, err
+		}
+		ids, err := readSlideIDs(files[presentationPart])
+		if err != nil {
+			return nil, err
+		}
+
+		var parts []string
+		for _, id := range ids {
+			if target, ok := rels[id]; ok {
+				parts = append(parts, target.target)
+			}
+		}
+		if len(parts) > 0 {
+			return parts, nil
+		}
+	}
+
+	type numbered struct {
+		name string
+		num  int
+	}
+	var slides []numbered
+	for name := range files {
+		if !strings.HasPrefix(name, "ppt/slides/slide") || !strings.HasSuffix(name, ".xml") {
+			continue
+		}
+		num, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "ppt/slides/slide"), ".xml"))
+		if err != nil {
+			continue
+		}
+		slides = append(slides, numbered{name: name, num: num})
+	}
+	sort.Slice(slides, func(i, j int) bool { return slides[i].num < slides[j].num })
+
+	parts := make([]string, 0, len(slides))
+	for _, s := range slides {
+		parts = append(parts, s.name)
+	}
+	return parts, nil
+}
+
+// readSlideIDs returns the relationship IDs of the slides listed in the presentation part.
+func readSlideIDs(f *zip.File) ([]string, error) {
+	var ids []string
+	err := walkXML(f, func(t xml.Token) error {
+		se, ok := t.(xml.StartElement)
+		if !ok || se.Name.Local != "sldId" {
+			return nil
+		}
+		for _, attr := range se.Attr {
+			// the r:id attribute, as opposed to the unqualified numeric id.
+			if attr.Name.Local == "id" && attr.Name.Space != "" {
+				ids = append(ids, attr.Value)
+			}
+		}
+		return nil
+	})
+	return ids, err
+}
+
+type relationship struct {
+	typ    string
+	target string
+}
+
+// readRels reads the relationships of a part, with targets resolved to part names in the archive.
+func readRels(files map[string]*zip.File, part string) (map[string]relationship, error) {
+	rels := make(map[string]relationship)
+	f, ok := files[path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")]
+	if !ok {
+		return rels, nil
+	}
+
+	err := walkXML(f, func(t xml.Token) error {
+		se, ok := t.(xml.StartElement)
+		if !ok || se.Name.Local != "Relationship" {
+			return nil
+		}
+		var id, typ, target, mode string
+		for _, attr := range se.Attr {
+			switch attr.Name.Local {
+			case "Id":
+				id = attr.Value
+			case "Type":
+				typ = attr.Value
+			case "Target":
+				target = attr.Value
+			case "TargetMode":
+				mode = attr.Value
+			}
+		}
+		if mode == "External" {
+			return nil
+		}
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(path.Dir(part), target)
+		}
+		rels[id] = relationship{typ: typ, target: target}
+		return nil
+	})
+	return rels, err
+}
+
+type slideContent struct {
+	title  string
+	text   string
+	notes  string
+	hidden bool
+}
+
+func readSlide(files map[string]*zip.File, part string) (*slideContent, error) {
+	f, ok := files[part]
+	if !ok {
+		return nil, fmt.Errorf("slide part not found: %s", part)
+	}
+
+	sc := &slideContent{}
+	blocks, hidden, err := readBlocks(f)
+	if err != nil {
+		return nil, err
+	}
+	sc.hidden = hidden
+
+	texts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		if sc.title == "" && (b.placeholder == "title" || b.placeholder == "ctrTitle") {
+			sc.title = strings.ReplaceAll(b.text, "\n", " ")
+		}
+		texts = append(texts, b.text)
+	}
+	sc.text = strings.Join(texts, "\n\n")
+
+	rels, err := readRels(files, part)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range rels {
+		if !strings.HasSuffix(rel.typ, notesSlideRel) {
+			continue
+		}
+		nf, ok := files[rel.target]
+		if !ok {
+			continue
+		}
+		noteBlocks, _, err := readBlocks(nf)
+		if err != nil {
+			return nil, err
+		}
+		var notes []string
+		for _, b := range noteBlocks {
+			// the notes page also holds placeholders for the slide image, number, header and footer.
+			if b.placeholder == "body" {
+				notes = append(notes, b.text)
+			}
+		}
+		sc.notes = strings.Join(notes, "\n\n")
+	}
+
+	return sc, nil
+}
+
+type textBlock struct {
+	// placeholder is the type of the placeholder the shape fills, empty for other shapes and tables.
+	placeholder string
+	text        string
+}
+
+// readBlocks extracts the text of every shape and table of a slide, in document order.
+// It also reports whether the slide is hidden.
+func readBlocks(f *zip.File) ([]textBlock, bool, error) {
+	var (
+		blocks []textBlock
+		hidden bool
+
+		shapeDepth  int
+		placeholder string
+		paragraphs  []string
+
+		para   strings.Builder
+		inText bool
+
+		table   [][]string
+		row     []string
+		cell    []string
+		inTable bool
+		inCell  bool
+	)
+
+	err := walkXML(f, func(t xml.Token) error {
+		switch tt := t.(type) {
+		case xml.StartElement:
+			switch tt.Name.Local {
+			case "sld":
+				for _, attr := range tt.Attr {
+					if attr.Name.Local == "show" && (attr.Value == "0" || attr.Value == "false") {
+						hidden = true
+					}
+				}
+			case "sp":
+				shapeDepth++
+				if shapeDepth == 1 {
+					placeholder, paragraphs = "", nil
+				}
+			case "ph":
+				if shapeDepth > 0 {
+					placeholder = "obj" // the default placeholder type
+					for _, attr := range tt.Attr {
+						if attr.Name.Local == "type" {
+							placeholder = attr.Value
+						}
+					}
+				}
+			case "tbl":
+				inTable, table = true, nil
+			case "tr":
+				row = nil
+			case "tc":
+				inCell, cell = true, nil
+			case "p":
+				para.Reset()
+			case "t":
+				inText = true
+			case "br":
+				para.WriteString("\n")
+			case "tab":
+				para.WriteString("\t")
+			}
+		case xml.CharData:
+			if inText {
+				para.Write(tt)
+			}
+		case xml.EndElement:
+			switch tt.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text := strings.TrimSpace(para.String())
+				if text == "" {
+					break
+				}
+				if inCell {
+					cell = append(cell, text)
+				} else if shapeDepth > 0 {
+					paragraphs = append(paragraphs, text)
+				}
+			case "tc":
+				inCell = false
+				row = append(row, strings.Join(cell, " "))
+			case "tr":
+				if inTable {
+					table = append(table, row)
+				}
+			case "tbl":
+				inTable = false
+				if text := renderTable(table); text != "" {
+					blocks = append(blocks, textBlock{text: text})
+				}
+			case "sp":
+				shapeDepth--
+				if shapeDepth == 0 && len(paragraphs) > 0 {
+					blocks = append(blocks, textBlock{
+						placeholder: placeholder,
+						text:        strings.Join(paragraphs, "\n"),
+					})
+				}
+			}
+		}
+		return nil
+	})
+
+	return blocks, hidden, err
+}
+
+func renderTable(rows [][]string) string {
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r))
+	}
+	if width == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, r := range rows {
+		cells := make([]string, width)
+		for j := range r {
+			cells[j] = strings.ReplaceAll(strings.ReplaceAll(r[j], "|", "\\|"), "\n", " ")
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, strings.Repeat("| --- ", width)+"|")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// walkXML calls fn with every token of the XML file.
+func walkXML(f *zip.File, fn func(t xml.Token) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	d := xml.NewDecoder(rc)
+	for {
+		t, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decode %s failed: %w", f.Name, err)
+		}
+		if err = fn(t); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	nsP = `xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`
+	nsA = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"`
+	nsR = `xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+)
+
+func shape(phType, text string) string {
+	ph := `<p:ph/>`
+	if phType != "" {
+		ph = `<p:ph type="` + phType + `"/>`
+	}
+	return `<p:sp><p:nvSpPr><p:nvPr>` + ph + `</p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></p:txBody></p:sp>`
+}
+
+func slide(attrs string, body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?><p:sld ` + nsP + ` ` + nsA + ` ` + nsR + ` ` + attrs + `><p:cSld><p:spTree>` + body + `</p:spTree></p:cSld></p:sld>`
+}
+
+func buildPPTX(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestPPTXParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	table := `<p:graphicFrame><a:graphic><a:graphicData><a:tbl>` +
+		`<a:tr><a:tc><a:txBody><a:p><a:r><a:t>Model</a:t></a:r></a:p></a:txBody></a:tc><a:tc><a:txBody><a:p><a:r><a:t>Latency</a:t></a:r></a:p></a:txBody></a:tc></a:tr>` +
+		`<a:tr><a:tc><a:txBody><a:p><a:r><a:t>ark</a:t></a:r></a:p></a:txBody></a:tc><a:tc><a:txBody><a:p><a:r><a:t>120ms</a:t></a:r></a:p></a:txBody></a:tc></a:tr>` +
+		`</a:tbl></a:graphicData></a:graphic></p:graphicFrame>`
+
+	files := map[string]string{
+		"ppt/presentation.xml": `<?xml version="1.0" encoding="UTF-8"?><p:presentation ` + nsP + ` ` + nsR + `><p:sldIdLst>` +
+			`<p:sldId id="257" r:id="rId3"/><p:sldId id="256" r:id="rId2"/><p:sldId id="258" r:id="rId4"/></p:sldIdLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<?xml version="1.0" encoding="UTF-8"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/>` +
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide2.xml"/>` +
+			`<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide3.xml"/>` +
+			`</Relationships>`,
+		// slide2.xml comes first in the presentation order.
+		"ppt/slides/slide2.xml": slide("", shape("ctrTitle", "Eino Overview")+shape("subTitle", "Components &amp; orchestration")),
+		"ppt/slides/_rels/slide2.xml.rels": `<?xml version="1.0" encoding="UTF-8"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com" TargetMode="External"/>` +
+			`</Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": `<?xml version="1.0" encoding="UTF-8"?><p:notes ` + nsP + ` ` + nsA + `><p:cSld><p:spTree>` +
+			shape("sldImg", "") + shape("body", "Welcome everyone") + shape("sldNum", "1") + `</p:spTree></p:cSld></p:notes>`,
+		"ppt/slides/slide1.xml": slide("", shape("title", "Benchmarks")+table),
+		"ppt/slides/slide3.xml": slide(`show="0"`, shape("title", "Backup")+shape("", "Hidden content")),
+	}
+	data := buildPPTX(t, files)
+
+	t.Run("TestPPTXParser_Default", func(t *testing.T) {
+		p, err := NewPPTXParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithURI("deck.pptx"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+
+		assert.Equal(t, "Eino Overview\n\nComponents & orchestration", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeySlide])
+		assert.Equal(t, 3, docs[0].MetaData[MetaKeyTotalSlides])
+		assert.Equal(t, "Eino Overview", docs[0].MetaData[MetaKeySlideTitle])
+		assert.Equal(t, "Welcome everyone", docs[0].MetaData[MetaKeyNotes])
+		assert.Equal(t, "deck.pptx", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+
+		assert.Equal(t, "Benchmarks\n\n| Model | Latency |\n| --- | --- |\n| ark | 120ms |", docs[1].Content)
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeySlide])
+		_, ok := docs[1].MetaData[MetaKeyNotes]
+		assert.False(t, ok)
+
+		assert.Equal(t, "Backup", docs[2].MetaData[MetaKeySlideTitle])
+	})
+
+	t.Run("TestPPTXParser_WithNotesAndSkipHidden", func(t *testing.T) {
+		p, err := NewPPTXParser(ctx, &Config{IncludeNotes: true, SkipHidden: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "Eino Overview\n\nComponents & orchestration\n\nNotes:\nWelcome everyone", docs[0].Content)
+	})
+
+	t.Run("TestPPTXParser_WithoutPresentationPart", func(t *testing.T) {
+		data := buildPPTX(t, map[string]string{
+			"ppt/slides/slide10.xml": slide("", shape("title", "Ten")),
+			"ppt/slides/slide2.xml":  slide("", shape("title", "Two")),
+		})
+
+		p, err := NewPPTXParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "Two", docs[0].Content)
+		assert.Equal(t, "Ten", docs[1].Content)
+	})
+
+	t.Run("TestPPTXParser_InvalidArchive", func(t *testing.T) {
+		p, err := NewPPTXParser(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+		assert.Error(t, err)
+	})
+}
@@ -20,7 +20,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
@@ -28,8 +31,9 @@ import (
 )
 
 const (
-	MetaDataRow = "_row"
-	MetaDataExt = "_ext"
+	MetaDataRow   = "_row"
+	MetaDataExt   = "_ext"
+	MetaDataSheet = "_sheet"
 )
 
 // XlsxParser Custom parser for parsing Xlsx file content
@@ -48,6 +52,18 @@ type Config struct {
 	NoHeader bool
 	// IDPrefix is set to customize the prefix of document ID, default 1,2,3, ...
 	IDPrefix string
+	// AllSheets processes every sheet in the workbook instead of a single one, SheetName is ignored.
+	// The document ID then contains the sheet name, e.g. Sheet1_1, Sheet1_2, ..., Sheet2_1, ...
+	AllSheets bool
+	// FillMergedCells copies the value of a merged cell into every cell covered by the merge,
+	// so that each row carries it, instead of only the top-left cell.
+	FillMergedCells bool
+	// TypedValues keeps the type of numeric, date and bool cells in the row metadata,
+	// as int64/float64, time.Time and bool respectively, instead of their formatted text.
+	TypedValues bool
+	// ToTable emits each sheet as a single document whose content is a Markdown table,
+	// instead of one document per row. Useful for small lookup tables.
+	ToTable bool
 }
 
 // NewXlsxParser Create a new xlsxParser
@@ -62,7 +78,10 @@ func NewXlsxParser(ctx context.Context, config *Config) (xlp parser.Parser, err
 }
 
 // generateID generates document ID based on configuration
-func (xlp *XlsxParser) generateID(i int) string {
+func (xlp *XlsxParser) generateID(sheetName string, i int) string {
+	if xlp.Config.AllSheets {
+		return fmt.Sprintf("%s%s_%d", xlp.Config.IDPrefix, sheetName, i)
+	}
 	if xlp.Config.IDPrefix == "" {
 		return fmt.Sprintf("%d", i)
 	}
@@ -70,7 +89,7 @@ func (xlp *XlsxParser) generateID(i int) string {
 }
 
 // buildRowMetaData builds row metadata from row data and headers
-func (xlp *XlsxParser) buildRowMetaData(row []string, headers []string) map[string]any {
+func (xlp *XlsxParser) buildRowMetaData(row []any, headers []string) map[string]any {
 	metaData := make(map[string]any)
 	if !xlp.Config.NoHeader {
 		for j, header := range headers {
@@ -98,11 +117,27 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 	}
 
 	// Default
-	sheetName := sheets[0]
-	if xlp.Config.SheetName != "" {
-		sheetName = xlp.Config.SheetName
+	sheetNames := []string{sheets[0]}
+	if xlp.Config.AllSheets {
+		sheetNames = sheets
+	} else if xlp.Config.SheetName != "" {
+		sheetNames = []string{xlp.Config.SheetName}
 	}
 
+	var ret []*schema.Document
+	for _, sheetName := range sheetNames {
+		docs, err := xlp.parseSheet(xlFile, sheetName, option)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, docs...)
+	}
+
+	return ret, nil
+}
+
+// parseSheet converts the rows of one sheet to documents.
+func (xlp *XlsxParser) parseSheet(xlFile *excelize.File, sheetName string, option *parser.Options) ([]*schema.Document, error) {
 	// Get all rows, header + data rows
 	rows, err := xlFile.GetRows(sheetName)
 	if err != nil {
@@ -112,6 +147,30 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 		return nil, nil
 	}
 
+	if xlp.Config.FillMergedCells {
+		if rows, err = fillMergedCells(xlFile, sheetName, rows); err != nil {
+			return nil, err
+		}
+	}
+
+	// Cell values as kept in the metadata, typed if required
+	values := make([][]any, len(rows))
+	for i, row := range rows {
+		values[i] = make([]any, len(row))
+		for j, cell := range row {
+			values[i][j] = cell
+		}
+	}
+	if xlp.Config.TypedValues {
+		if err = xlp.typeValues(xlFile, sheetName, values); err != nil {
+			return nil, err
+		}
+	}
+
+	if xlp.Config.ToTable {
+		return []*schema.Document{xlp.buildTableDocument(sheetName, rows, option)}, nil
+	}
+
 	var ret []*schema.Document
 
 	// Process the header
@@ -138,8 +197,9 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 		meta := make(map[string]any)
 
 		// Build the row's Meta
-		rowMeta := xlp.buildRowMetaData(row, headers)
+		rowMeta := xlp.buildRowMetaData(values[i], headers)
 		meta[MetaDataRow] = rowMeta
+		meta[MetaDataSheet] = sheetName
 
 		// Get the Common ExtraMeta
 		if option.ExtraMeta != nil {
@@ -148,7 +208,7 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 
 		// Create New Document
 		nDoc := &schema.Document{
-			ID:       xlp.generateID(i),
+			ID:       xlp.generateID(sheetName, i),
 			Content:  content,
 			MetaData: meta,
 		}
@@ -158,3 +218,243 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 
 	return ret, nil
 }
+
+// buildTableDocument renders the whole sheet as a Markdown table.
+// Without a header row, the columns are named Column 1, Column 2, ...
+func (xlp *XlsxParser) buildTableDocument(sheetName string, rows [][]string, option *parser.Options) *schema.Document {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	headers := make([]string, width)
+	body := rows
+	if !xlp.Config.NoHeader {
+		copy(headers, rows[0])
+		body = rows[1:]
+	} else {
+		for j := range headers {
+			headers[j] = fmt.Sprintf("Column %d", j+1)
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(cells) {
+				cell = escapeTableCell(cells[j])
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(headers)
+	sb.WriteString(strings.Repeat("| --- ", width) + "|\n")
+	for _, row := range body {
+		if len(row) == 0 {
+			continue
+		}
+		writeRow(row)
+	}
+
+	meta := map[string]any{
+		MetaDataSheet: sheetName,
+	}
+	if option.ExtraMeta != nil {
+		meta[MetaDataExt] = option.ExtraMeta
+	}
+
+	return &schema.Document{
+		ID:       xlp.Config.IDPrefix + sheetName,
+		Content:  strings.TrimSuffix(sb.String(), "\n"),
+		MetaData: meta,
+	}
+}
+
+func escapeTableCell(cell string) string {
+	cell = strings.TrimSpace(cell)
+	cell = strings.ReplaceAll(cell, "|", "\\|")
+	cell = strings.ReplaceAll(cell, "\r\n", " ")
+	return strings.ReplaceAll(cell, "\n", " ")
+}
+
+// fillMergedCells sets the value of every merged range to all the cells it covers.
+func fillMergedCells(xlFile *excelize.File, sheetName string, rows [][]string) ([][]string, error) {
+	mergeCells, err := xlFile.GetMergeCells(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mc := range mergeCells {
+		startCol, startRow, err := excelize.CellNameToCoordinates(mc.GetStartAxis())
+		if err != nil {
+			return nil, err
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(mc.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+
+		// the value of the top-left cell, as formatted by GetRows
+		value := mc.GetCellValue()
+		if startRow-1 < len(rows) && startCol-1 < len(rows[startRow-1]) {
+			value = rows[startRow-1][startCol-1]
+		}
+
+		for r := startRow - 1; r < endRow; r++ {
+			for r >= len(rows) {
+				rows = append(rows, nil)
+			}
+			for len(rows[r]) < endCol {
+				rows[r] = append(rows[r], "")
+			}
+			for c := startCol - 1; c < endCol; c++ {
+				rows[r][c] = value
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// typeValues replaces the formatted values of numeric, date and bool cells with typed values.
+// Merged cells are resolved to their top-left cell, so filled values get the same type.
+func (xlp *XlsxParser) typeValues(xlFile *excelize.File, sheetName string, values [][]any) error {
+	props, err := xlFile.GetWorkbookProps()
+	if err != nil {
+		return err
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	origins := make(map[[2]int]string)
+	if xlp.Config.FillMergedCells {
+		mergeCells, err := xlFile.GetMergeCells(sheetName)
+		if err != nil {
+			return err
+		}
+		for _, mc := range mergeCells {
+			startCol, startRow, _ := excelize.CellNameToCoordinates(mc.GetStartAxis())
+			endCol, endRow, _ := excelize.CellNameToCoordinates(mc.GetEndAxis())
+			for r := startRow; r <= endRow; r++ {
+				for c := startCol; c <= endCol; c++ {
+					origins[[2]int{c, r}] = mc.GetStartAxis()
+				}
+			}
+		}
+	}
+
+	for i, row := range values {
+		for j := range row {
+			cell, ok := origins[[2]int{j + 1, i + 1}]
+			if !ok {
+				cell, err = excelize.CoordinatesToCellName(j+1, i+1)
+				if err != nil {
+					return err
+				}
+			}
+			if v, ok := typedValue(xlFile, sheetName, cell, date1904); ok {
+				row[j] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+// typedValue returns the typed value of a cell, or false if it should be kept as formatted text.
+func typedValue(xlFile *excelize.File, sheetName, cell string, date1904 bool) (any, bool) {
+	cellType, err := xlFile.GetCellType(sheetName, cell)
+	if err != nil {
+		return nil, false
+	}
+	raw, err := xlFile.GetCellValue(sheetName, cell, excelize.Options{RawCellValue: true})
+	if err != nil || raw == "" {
+		return nil, false
+	}
+
+	switch cellType {
+	case excelize.CellTypeBool:
+		return raw == "1" || strings.EqualFold(raw, "true"), true
+	case excelize.CellTypeDate:
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, false
+		}
+		return t, true
+	case excelize.CellTypeNumber, excelize.CellTypeUnset:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, false
+		}
+		if isDateCell(xlFile, sheetName, cell) {
+			t, err := excelize.ExcelDateToTime(n, date1904)
+			if err != nil {
+				return nil, false
+			}
+			return t, true
+		}
+		if n == math.Trunc(n) && math.Abs(n) < 1<<53 {
+			return int64(n), true
+		}
+		return n, true
+	default:
+		return nil, false
+	}
+}
+
+// isDateCell reports whether the number format of the cell displays a date or time.
+func isDateCell(xlFile *excelize.File, sheetName, cell string) bool {
+	styleID, err := xlFile.GetCellStyle(sheetName, cell)
+	if err != nil {
+		return false
+	}
+	style, err := xlFile.GetStyle(styleID)
+	if err != nil || style == nil {
+		return false
+	}
+	if style.CustomNumFmt != nil {
+		return isDateFormatCode(*style.CustomNumFmt)
+	}
+	return isDateNumFmt(style.NumFmt)
+}
+
+// isDateNumFmt reports whether the built-in number format ID displays a date or time,
+// including the built-in formats of the CJK locales.
+func isDateNumFmt(id int) bool {
+	switch {
+	case id >= 14 && id <= 22, id >= 45 && id <= 47:
+		return true
+	case id >= 27 && id <= 36, id >= 50 && id <= 58:
+		return true
+	default:
+		return false
+	}
+}
+
+// isDateFormatCode reports whether a custom number format code contains date or time tokens,
+// ignoring quoted literals, escaped characters and bracketed sections such as colors.
+func isDateFormatCode(code string) bool {
+	var quoted, bracketed, escaped bool
+	for _, c := range strings.ToLower(code) {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted:
+			quoted = c != '"'
+		case bracketed:
+			bracketed = c != ']'
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = true
+		case c == '[':
+			bracketed = true
+		case strings.ContainsRune("ymdhs", c):
+			return true
+		}
+	}
+	return false
+}
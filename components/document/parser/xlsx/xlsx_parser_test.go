@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
@@ -102,4 +103,77 @@ func TestXlsxParser_Parse(t *testing.T) {
 		assert.Equal(t, map[string]any{}, docs[0].MetaData[MetaDataRow])
 		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData[MetaDataExt])
 	})
+
+	t.Run("TestXlsxParser_WithAllSheets", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./examples/testdata/test_typed.xlsx")
+		assert.NoError(t, err)
+
+		p, err := NewXlsxParser(ctx, &Config{
+			AllSheets: true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(docs))
+		assert.Equal(t, "Employees_1", docs[0].ID)
+		assert.Equal(t, "Employees", docs[0].MetaData[MetaDataSheet])
+		assert.Equal(t, "Lookup_2", docs[3].ID)
+		assert.Equal(t, "Lookup", docs[3].MetaData[MetaDataSheet])
+		// without filling, only the top-left cell of a merged range has a value
+		assert.Equal(t, "", docs[1].MetaData[MetaDataRow].(map[string]any)["team"])
+		_, ok := docs[0].MetaData[MetaDataExt]
+		assert.False(t, ok)
+	})
+
+	t.Run("TestXlsxParser_WithMergedCellsAndTypedValues", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./examples/testdata/test_typed.xlsx")
+		assert.NoError(t, err)
+
+		p, err := NewXlsxParser(ctx, &Config{
+			FillMergedCells: true,
+			TypedValues:     true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, map[string]any{
+			"name":   "Alice",
+			"team":   "Search",
+			"age":    int64(30),
+			"score":  9.5,
+			"joined": time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			"active": true,
+		}, docs[0].MetaData[MetaDataRow])
+		assert.Equal(t, "Search", docs[1].MetaData[MetaDataRow].(map[string]any)["team"])
+		assert.Equal(t, false, docs[1].MetaData[MetaDataRow].(map[string]any)["active"])
+		assert.Contains(t, docs[1].Content, "Search")
+	})
+
+	t.Run("TestXlsxParser_WithToTable", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./examples/testdata/test_typed.xlsx")
+		assert.NoError(t, err)
+
+		p, err := NewXlsxParser(ctx, &Config{
+			SheetName: "Lookup",
+			ToTable:   true,
+			IDPrefix:  "table_",
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "table_Lookup", docs[0].ID)
+		assert.Equal(t, "| code | meaning |\n| --- | --- |\n| E1 | a\\|b |\n| E2 | c |", docs[0].Content)
+		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData[MetaDataExt])
+	})
 }
@@ -0,0 +1,358 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyDuplicateIDs is set on the document kept for a group of near-duplicates to the IDs of the dropped ones, []string.
+	MetaKeyDuplicateIDs = "_duplicate_ids"
+)
+
+// Method is the signature used to estimate the similarity of documents.
+type Method string
+
+const (
+	// MethodMinHash estimates the Jaccard similarity of the shingle sets of the documents.
+	// Accurate for any threshold, the default.
+	MethodMinHash Method = "minhash"
+	// MethodSimHash compares 64 bits fingerprints of the documents, similarity being the share of equal bits.
+	// Cheaper than MinHash, suited to high thresholds, i.e. nearly identical pages.
+	MethodSimHash Method = "simhash"
+)
+
+// Keep selects the document kept from a group of near-duplicates.
+type Keep string
+
+const (
+	// KeepFirst keeps the first document of the group in input order, the default.
+	KeepFirst Keep = "first"
+	// KeepLongest keeps the document with the longest content.
+	KeepLongest Keep = "longest"
+	// KeepNewest keeps the document with the latest time in the TimeKey metadata.
+	KeepNewest Keep = "newest"
+)
+
+const (
+	defaultShingleSize      = 3
+	defaultNumHashes        = 128
+	defaultMinHashThreshold = 0.8
+	defaultSimHashThreshold = 0.9
+	simHashBits             = 64
+)
+
+type Config struct {
+	// Method of similarity estimation, MethodMinHash by default.
+	Method Method
+	// Threshold is the similarity from which documents are near-duplicates, in (0, 1].
+	// Defaults to 0.8 for MethodMinHash and to 0.9, i.e. at most 6 different bits, for MethodSimHash.
+	Threshold float64
+	// ShingleSize is the number of consecutive words of a shingle, 3 by default.
+	// Words are runs of letters and digits, every CJK character is a word of its own.
+	ShingleSize int
+	// NumHashes is the number of hash functions of MethodMinHash, 128 by default.
+	// More hashes estimate the similarity more precisely, at a higher cost.
+	NumHashes int
+	// Keep selects the document kept from each group of near-duplicates, KeepFirst by default.
+	Keep Keep
+	// TimeKey is the metadata key of the document time used by KeepNewest, required by it.
+	// Values are time.Time, strings in RFC 3339 or "2006-01-02" format, or unix seconds.
+	// Documents without valid time are the oldest.
+	TimeKey string
+}
+
+// NewDeduplicator creates a transformer dropping near-duplicate documents.
+//
+// Documents are cut into shingles of ShingleSize words, signed with MinHash or SimHash, and grouped with the
+// documents their similarity is above Threshold with, transitively. Candidate pairs are found by locality sensitive hashing
+// on the signatures, so that not every pair of documents is compared.
+// One document of every group is kept, in input order, as a copy carrying the IDs of the dropped ones in MetaKeyDuplicateIDs.
+func NewDeduplicator(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	d := &deduplicator{
+		method:      config.Method,
+		threshold:   config.Threshold,
+		shingleSize: config.ShingleSize,
+		numHashes:   config.NumHashes,
+		keep:        config.Keep,
+		timeKey:     config.TimeKey,
+	}
+	if d.method == "" {
+		d.method = MethodMinHash
+	}
+	if d.shingleSize == 0 {
+		d.shingleSize = defaultShingleSize
+	}
+	if d.numHashes == 0 {
+		d.numHashes = defaultNumHashes
+	}
+	if d.keep == "" {
+		d.keep = KeepFirst
+	}
+
+	switch d.method {
+	case MethodMinHash:
+		if d.threshold == 0 {
+			d.threshold = defaultMinHashThreshold
+		}
+	case MethodSimHash:
+		if d.threshold == 0 {
+			d.threshold = defaultSimHashThreshold
+		}
+	default:
+		return nil, fmt.Errorf("unknown dedupe method: %s", d.method)
+	}
+	if d.threshold < 0 || d.threshold > 1 {
+		return nil, fmt.Errorf("threshold must be in (0, 1], got %v", d.threshold)
+	}
+	if d.shingleSize < 0 || d.numHashes < 0 {
+		return nil, fmt.Errorf("shingle size and num hashes must not be negative")
+	}
+	switch d.keep {
+	case KeepFirst, KeepLongest:
+	case KeepNewest:
+		if d.timeKey == "" {
+			return nil, fmt.Errorf("time key is required to keep the newest documents")
+		}
+	default:
+		return nil, fmt.Errorf("unknown keep strategy: %s", d.keep)
+	}
+	return d, nil
+}
+
+type deduplicator struct {
+	method      Method
+	threshold   float64
+	shingleSize int
+	numHashes   int
+	keep        Keep
+	timeKey     string
+}
+
+func (d *deduplicator) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	groups := newUnionFind(len(src))
+	if d.method == MethodMinHash {
+		d.groupMinHash(src, groups)
+	} else {
+		d.groupSimHash(src, groups)
+	}
+
+	members := make(map[int][]int)
+	for i := range src {
+		root := groups.find(i)
+		members[root] = append(members[root], i)
+	}
+
+	ret := make([]*schema.Document, 0, len(members))
+	for i, doc := range src {
+		group := members[groups.find(i)]
+		if len(group) == 1 {
+			ret = append(ret, doc)
+			continue
+		}
+		kept := d.selectKept(src, group)
+		if kept != i {
+			continue
+		}
+
+		dropped := make([]string, 0, len(group)-1)
+		for _, j := range group {
+			if j != kept {
+				dropped = append(dropped, src[j].ID)
+			}
+		}
+		copied := &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: make(map[string]any, len(doc.MetaData)+1),
+		}
+		for k, v := range doc.MetaData {
+			copied.MetaData[k] = v
+		}
+		copied.MetaData[MetaKeyDuplicateIDs] = dropped
+		ret = append(ret, copied)
+	}
+	return ret, nil
+}
+
+func (d *deduplicator) groupMinHash(src []*schema.Document, groups *unionFind) {
+	sigs := make([][]uint64, len(src))
+	for i, doc := range src {
+		if sh := shingles(tokenize(doc.Content), d.shingleSize); len(sh) > 0 {
+			sigs[i] = minHash(sh, d.numHashes)
+		}
+	}
+
+	bands := lshBands(d.numHashes, d.threshold)
+	rows := d.numHashes / bands
+	buf := make([]byte, 8)
+	for b := 0; b < bands; b++ {
+		buckets := make(map[uint64][]int)
+		for i, sig := range sigs {
+			if sig == nil {
+				continue
+			}
+			h := fnv.New64a()
+			for _, v := range sig[b*rows : (b+1)*rows] {
+				binary.LittleEndian.PutUint64(buf, v)
+				_, _ = h.Write(buf)
+			}
+			key := h.Sum64()
+			buckets[key] = append(buckets[key], i)
+		}
+		for _, bucket := range buckets {
+			groups.unionSimilar(bucket, func(i, j int) bool {
+				return jaccard(sigs[i], sigs[j]) >= d.threshold
+			})
+		}
+	}
+}
+
+func (d *deduplicator) groupSimHash(src []*schema.Document, groups *unionFind) {
+	var (
+		sigs  = make([]uint64, len(src))
+		valid = make([]bool, len(src))
+	)
+	for i, doc := range src {
+		if sh := shingles(tokenize(doc.Content), d.shingleSize); len(sh) > 0 {
+			sigs[i], valid[i] = simHash(sh), true
+		}
+	}
+
+	// fingerprints differing by at most maxDist bits have at least one of maxDist+1 bands in common
+	maxDist := int((1 - d.threshold) * simHashBits)
+	bands := maxDist + 1
+	start := 0
+	for b := 0; b < bands; b++ {
+		width := simHashBits / bands
+		if b < simHashBits%bands {
+			width++
+		}
+		mask := (uint64(1)<<uint(width) - 1) << uint(start)
+		if width == simHashBits {
+			mask = ^uint64(0)
+		}
+		start += width
+
+		buckets := make(map[uint64][]int)
+		for i, sig := range sigs {
+			if valid[i] {
+				buckets[sig&mask] = append(buckets[sig&mask], i)
+			}
+		}
+		for _, bucket := range buckets {
+			groups.unionSimilar(bucket, func(i, j int) bool {
+				return hamming(sigs[i], sigs[j]) <= maxDist
+			})
+		}
+	}
+}
+
+// selectKept returns the index of the document kept from a group, ties are won by the first document.
+func (d *deduplicator) selectKept(src []*schema.Document, group []int) int {
+	kept := group[0]
+	switch d.keep {
+	case KeepLongest:
+		for _, i := range group[1:] {
+			if utf8.RuneCountInString(src[i].Content) > utf8.RuneCountInString(src[kept].Content) {
+				kept = i
+			}
+		}
+	case KeepNewest:
+		keptTime := d.timeOf(src[kept])
+		for _, i := range group[1:] {
+			if t := d.timeOf(src[i]); t.After(keptTime) {
+				kept, keptTime = i, t
+			}
+		}
+	}
+	return kept
+}
+
+func (d *deduplicator) timeOf(doc *schema.Document) time.Time {
+	switch v := doc.MetaData[d.timeKey].(type) {
+	case time.Time:
+		return v
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t
+			}
+		}
+	case int:
+		return time.Unix(int64(v), 0)
+	case int64:
+		return time.Unix(v, 0)
+	case float64:
+		return time.Unix(int64(v), 0)
+	}
+	return time.Time{}
+}
+
+func (d *deduplicator) GetType() string {
+	return "NearDuplicateFilter"
+}
+
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+// unionSimilar joins the groups of the similar pairs of a bucket of candidates.
+func (u *unionFind) unionSimilar(bucket []int, similar func(i, j int) bool) {
+	for x := 0; x < len(bucket); x++ {
+		for y := x + 1; y < len(bucket); y++ {
+			i, j := bucket[x], bucket[y]
+			ri, rj := u.find(i), u.find(j)
+			if ri == rj || !similar(i, j) {
+				continue
+			}
+			if ri < rj {
+				u.parent[rj] = ri
+			} else {
+				u.parent[ri] = rj
+			}
+		}
+	}
+}
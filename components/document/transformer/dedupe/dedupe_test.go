@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const page = `Eino is the ultimate LLM application development framework in Golang. Drawing inspiration from many excellent
+LLM application development frameworks in the open-source community such as LangChain and LlamaIndex, as well as learning
+from cutting-edge research and real world applications, Eino offers an LLM application development framework that
+emphasizes on simplicity, scalability, reliability and effectiveness that better aligns with Golang programming conventions.`
+
+const otherPage = `CloudWeGo is an enterprise-level middleware and cloud native microservice framework. It has the characteristics
+of high performance, strong extensibility and high reliability, and focuses on microservice communication and governance.`
+
+func testDocs() []*schema.Document {
+	return []*schema.Document{
+		{ID: "1", Content: page, MetaData: map[string]any{"updated": "2024-01-02"}},
+		{ID: "2", Content: otherPage},
+		// the same page with another footer
+		{ID: "3", Content: page + "\nCopyright 2025.", MetaData: map[string]any{"updated": time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		// the same page with different punctuation and case
+		{ID: "4", Content: strings.ToUpper(strings.ReplaceAll(page, ",", ";")), MetaData: map[string]any{"updated": 1700000000}},
+		{ID: "5", Content: "  "},
+		{ID: "6", Content: "  "},
+	}
+}
+
+func ids(docs []*schema.Document) []string {
+	var ret []string
+	for _, doc := range docs {
+		ret = append(ret, doc.ID)
+	}
+	return ret
+}
+
+func TestDeduplicator(t *testing.T) {
+	ctx := context.Background()
+
+	for _, method := range []Method{MethodMinHash, MethodSimHash} {
+		t.Run(string(method), func(t *testing.T) {
+			d, err := NewDeduplicator(ctx, &Config{Method: method})
+			assert.NoError(t, err)
+
+			src := testDocs()
+			docs, err := d.Transform(ctx, src)
+			assert.NoError(t, err)
+			// documents without words are never duplicates
+			assert.Equal(t, []string{"1", "2", "5", "6"}, ids(docs))
+			assert.Equal(t, []string{"3", "4"}, docs[0].MetaData[MetaKeyDuplicateIDs])
+			assert.Equal(t, "2024-01-02", docs[0].MetaData["updated"])
+			assert.NotContains(t, docs[1].MetaData, MetaKeyDuplicateIDs)
+			// input documents are left untouched
+			assert.NotContains(t, src[0].MetaData, MetaKeyDuplicateIDs)
+		})
+	}
+
+	t.Run("keep", func(t *testing.T) {
+		d, err := NewDeduplicator(ctx, &Config{Keep: KeepLongest})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, testDocs())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "3", "5", "6"}, ids(docs))
+		assert.Equal(t, []string{"1", "4"}, docs[1].MetaData[MetaKeyDuplicateIDs])
+
+		d, err = NewDeduplicator(ctx, &Config{Keep: KeepNewest, TimeKey: "updated"})
+		assert.NoError(t, err)
+		docs, err = d.Transform(ctx, testDocs())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "3", "5", "6"}, ids(docs))
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		half := page[:len(page)/2]
+		src := []*schema.Document{{ID: "1", Content: page}, {ID: "2", Content: half}}
+
+		d, err := NewDeduplicator(ctx, &Config{})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+
+		d, err = NewDeduplicator(ctx, &Config{Threshold: 0.3})
+		assert.NoError(t, err)
+		docs, err = d.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids(docs))
+	})
+
+	t.Run("cjk", func(t *testing.T) {
+		text := "Eino 是基于 Golang 的大模型应用开发框架，借鉴了开源社区中诸多优秀的大模型应用开发框架，强调简洁性、可扩展性、可靠性与有效性。"
+		d, err := NewDeduplicator(ctx, &Config{})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, []*schema.Document{
+			{ID: "1", Content: text},
+			{ID: "2", Content: strings.Replace(text, "有效性", "高效性", 1)},
+			{ID: "3", Content: "CloudWeGo 是一套由字节跳动开源的、以 Go 语言为核心的中间件集合。"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "3"}, ids(docs))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewDeduplicator(ctx, &Config{Keep: KeepNewest})
+		assert.Error(t, err)
+		_, err = NewDeduplicator(ctx, &Config{Method: "exact"})
+		assert.Error(t, err)
+		_, err = NewDeduplicator(ctx, &Config{Threshold: 1.5})
+		assert.Error(t, err)
+	})
+}
+
+func TestLSHBands(t *testing.T) {
+	assert.Equal(t, 16, lshBands(128, 0.8))
+	assert.Equal(t, 8, lshBands(128, 0.9))
+	assert.Equal(t, 32, lshBands(128, 0.5))
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// tokenize splits text into lower case words, every CJK character being a word of its own.
+func tokenize(text string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// shingles returns the distinct hashes of the runs of size consecutive tokens,
+// texts shorter than size are a single shingle.
+func shingles(tokens []string, size int) []uint64 {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if len(tokens) < size {
+		size = len(tokens)
+	}
+	seen := make(map[uint64]struct{}, len(tokens)-size+1)
+	ret := make([]uint64, 0, len(tokens)-size+1)
+	for i := 0; i+size <= len(tokens); i++ {
+		h := fnv.New64a()
+		for _, t := range tokens[i : i+size] {
+			_, _ = h.Write([]byte(t))
+			_, _ = h.Write([]byte{0})
+		}
+		sum := h.Sum64()
+		if _, ok := seen[sum]; ok {
+			continue
+		}
+		seen[sum] = struct{}{}
+		ret = append(ret, sum)
+	}
+	return ret
+}
+
+// mix is the splitmix64 finalizer, used to derive independent hash functions from seeds.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// minHash returns the minimum of every one of numHashes hash functions over the shingles.
+func minHash(shingles []uint64, numHashes int) []uint64 {
+	sig := make([]uint64, numHashes)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for _, s := range shingles {
+		for i := range sig {
+			if h := mix(s ^ mix(uint64(i)+1)); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+	return sig
+}
+
+// jaccard estimates the Jaccard similarity of the shingle sets from their MinHash signatures.
+func jaccard(a, b []uint64) float64 {
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// lshBands chooses the number of bands of the MinHash signature,
+// the highest similarity threshold (1/b)^(1/r) of the banding not above threshold, so that pairs above it are found.
+func lshBands(numHashes int, threshold float64) int {
+	best, bestT := numHashes, 0.0
+	for b := 1; b <= numHashes; b++ {
+		if numHashes%b != 0 {
+			continue
+		}
+		r := numHashes / b
+		t := math.Pow(1/float64(b), 1/float64(r))
+		if t <= threshold && t > bestT {
+			best, bestT = b, t
+		}
+	}
+	return best
+}
+
+// simHash returns the 64 bits SimHash of the shingles, each bit is the majority vote of the shingle hashes.
+func simHash(shingles []uint64) uint64 {
+	var votes [64]int
+	for _, s := range shingles {
+		h := mix(s)
+		for i := range votes {
+			if h&(1<<uint(i)) != 0 {
+				votes[i]++
+			} else {
+				votes[i]--
+			}
+		}
+	}
+	var sig uint64
+	for i, v := range votes {
+		if v > 0 {
+			sig |= 1 << uint(i)
+		}
+	}
+	return sig
+}
+
+func hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyContext is the context generated for the chunk, also prepended to its content.
+	MetaKeyContext = "_context"
+	// MetaKeyOriginalContent is the content of the chunk before the context is prepended.
+	MetaKeyOriginalContent = "_original_content"
+)
+
+const contextualSystemPrompt = "You situate chunks of documents within their document to improve search retrieval."
+
+// DefaultContextualPrompt is the prompt from Anthropic's contextual retrieval, https://www.anthropic.com/news/contextual-retrieval.
+const DefaultContextualPrompt = `<document>
+{document}
+</document>
+Here is the chunk we want to situate within the whole document
+<chunk>
+{chunk}
+</chunk>
+Please give a short succinct context to situate this chunk within the overall document for the purposes of improving search retrieval of the chunk. Answer only with the succinct context and nothing else.`
+
+type ContextualConfig struct {
+	// ChatModel generates the context of every chunk, required.
+	ChatModel model.BaseChatModel
+	// ModelOptions are passed to every call of ChatModel, e.g. model.WithTemperature(0).
+	ModelOptions []model.Option
+
+	// Splitter splits the input documents into chunks, the context of a chunk is generated from the document it comes from.
+	// If nil, the input documents are the chunks and ParentContent is required.
+	Splitter document.Transformer
+	// ParentContent returns the content of the document a chunk comes from, e.g. by looking up its "_parent_id" metadata.
+	// Only used if Splitter is nil.
+	ParentContent func(ctx context.Context, chunk *schema.Document) (string, error)
+
+	// Prompt is the user prompt, {document} is replaced by the parent document and {chunk} by the chunk.
+	// Defaults to DefaultContextualPrompt.
+	Prompt string
+	// MaxDocumentLength truncates the parent document in the prompt to its first MaxDocumentLength runes, if set.
+	MaxDocumentLength int
+
+	// Concurrency is the number of chunks contextualized in parallel, 1 by default.
+	Concurrency int
+	// OnDocumentError is called with the chunk when its context can not be generated.
+	// Returning nil keeps the chunk without context, returning an error aborts the whole transform.
+	// Defaults to logging the error and keeping the chunk.
+	OnDocumentError ErrorHandler
+}
+
+// NewContextualizer creates a transformer prepending to every chunk a short context situating it in its parent document,
+// generated by a chat model, a.k.a. contextual retrieval. Chunks are then found by searches on the topic of their document
+// even if the chunk itself does not mention it, e.g. a chunk about "the second quarter" of a report on some company.
+//
+// The output chunks are copies of the input ones, the context is also kept in MetaKeyContext and the chunk
+// as it was in MetaKeyOriginalContent.
+func NewContextualizer(ctx context.Context, config *ContextualConfig) (document.Transformer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if config.Splitter == nil && config.ParentContent == nil {
+		return nil, fmt.Errorf("either splitter or parent content is required")
+	}
+	prompt := config.Prompt
+	if prompt == "" {
+		prompt = DefaultContextualPrompt
+	}
+	if err := checkPrompt(prompt, "document", "chunk"); err != nil {
+		return nil, err
+	}
+	r, err := newRunner("contextualizer", config.ChatModel, config.ModelOptions, config.Concurrency, config.OnDocumentError)
+	if err != nil {
+		return nil, err
+	}
+	return &contextualizer{
+		runner:            r,
+		splitter:          config.Splitter,
+		parentContent:     config.ParentContent,
+		prompt:            prompt,
+		maxDocumentLength: config.MaxDocumentLength,
+	}, nil
+}
+
+type contextualizer struct {
+	*runner
+	splitter          document.Transformer
+	parentContent     func(ctx context.Context, chunk *schema.Document) (string, error)
+	prompt            string
+	maxDocumentLength int
+}
+
+func (c *contextualizer) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var (
+		chunks  []*schema.Document
+		parents []string
+	)
+	if c.splitter != nil {
+		for _, doc := range src {
+			split, err := c.splitter.Transform(ctx, []*schema.Document{doc}, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("split document [%s] failed: %w", doc.ID, err)
+			}
+			for range split {
+				parents = append(parents, doc.Content)
+			}
+			chunks = append(chunks, split...)
+		}
+	} else {
+		chunks = src
+		parents = make([]string, len(src))
+	}
+
+	ret := make([]*schema.Document, len(chunks))
+	err := c.run(ctx, chunks, func(ctx context.Context, i int, chunk *schema.Document) error {
+		ret[i] = chunk
+		parent := parents[i]
+		if c.splitter == nil {
+			var err error
+			parent, err = c.parentContent(ctx, chunk)
+			if err != nil {
+				return fmt.Errorf("get parent content failed: %w", err)
+			}
+		}
+
+		text, err := c.generate(ctx, contextualSystemPrompt, formatPrompt(c.prompt, map[string]string{
+			"document": truncate(parent, c.maxDocumentLength),
+			"chunk":    chunk.Content,
+		}))
+		if err != nil {
+			return err
+		}
+
+		copied := copyDocument(chunk)
+		copied.Content = text + "\n\n" + chunk.Content
+		copied.MetaData[MetaKeyContext] = text
+		copied.MetaData[MetaKeyOriginalContent] = chunk.Content
+		ret[i] = copied
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (c *contextualizer) GetType() string {
+	return "LLMContextualizer"
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultConcurrency = 1
+
+// ErrorHandler is called when a single document can not be transformed.
+// Returning nil keeps the document unchanged and continues, returning an error aborts the whole transform.
+type ErrorHandler func(ctx context.Context, doc *schema.Document, err error) error
+
+func defaultErrorHandler(name string) ErrorHandler {
+	return func(ctx context.Context, doc *schema.Document, err error) error {
+		log.Printf("%s keep document [%s] unchanged: %v", name, doc.ID, err)
+		return nil
+	}
+}
+
+// runner calls a chat model for every document, concurrency documents at a time.
+type runner struct {
+	chatModel    model.BaseChatModel
+	modelOptions []model.Option
+	concurrency  int
+	onError      ErrorHandler
+}
+
+func newRunner(name string, chatModel model.BaseChatModel, modelOptions []model.Option, concurrency int, onError ErrorHandler) (*runner, error) {
+	if chatModel == nil {
+		return nil, fmt.Errorf("chat model is required")
+	}
+	if concurrency < 0 {
+		return nil, fmt.Errorf("concurrency must not be negative, got %d", concurrency)
+	}
+	if concurrency == 0 {
+		concurrency = defaultConcurrency
+	}
+	if onError == nil {
+		onError = defaultErrorHandler(name)
+	}
+	return &runner{
+		chatModel:    chatModel,
+		modelOptions: modelOptions,
+		concurrency:  concurrency,
+		onError:      onError,
+	}, nil
+}
+
+// run calls fn for every document, documents fn fails on are passed to the error handler.
+func (r *runner) run(ctx context.Context, docs []*schema.Document, fn func(ctx context.Context, i int, doc *schema.Document) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		sem      = make(chan struct{}, r.concurrency)
+		wg       sync.WaitGroup
+		once     sync.Once
+		abortErr error
+	)
+	for i, doc := range docs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, doc *schema.Document) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := fn(ctx, i, doc)
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+			if hErr := r.onError(ctx, doc, err); hErr != nil {
+				once.Do(func() {
+					abortErr = hErr
+					cancel()
+				})
+			}
+		}(i, doc)
+	}
+	wg.Wait()
+
+	if abortErr != nil {
+		return abortErr
+	}
+	return ctx.Err()
+}
+
+func (r *runner) generate(ctx context.Context, system, user string) (string, error) {
+	msg, err := r.chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(system),
+		schema.UserMessage(user),
+	}, r.modelOptions...)
+	if err != nil {
+		return "", fmt.Errorf("generate failed: %w", err)
+	}
+	content := strings.TrimSpace(msg.Content)
+	if content == "" {
+		return "", fmt.Errorf("chat model returns empty content")
+	}
+	return content, nil
+}
+
+// formatPrompt replaces the {name} placeholders of prompt in a single pass,
+// so that placeholders appearing in the values are left as is.
+func formatPrompt(prompt string, values map[string]string) string {
+	var oldnew []string
+	for k, v := range values {
+		oldnew = append(oldnew, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(oldnew...).Replace(prompt)
+}
+
+func checkPrompt(prompt string, placeholders ...string) error {
+	for _, p := range placeholders {
+		if !strings.Contains(prompt, "{"+p+"}") {
+			return fmt.Errorf("prompt must contain the {%s} placeholder", p)
+		}
+	}
+	return nil
+}
+
+// truncate keeps the first maxLen runes of s, s is kept whole if maxLen is zero.
+func truncate(s string, maxLen int) string {
+	if maxLen <= 0 {
+		return s
+	}
+	n := 0
+	for i := range s {
+		if n == maxLen {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
+
+func copyDocument(doc *schema.Document) *schema.Document {
+	copied := &schema.Document{
+		ID:       doc.ID,
+		Content:  doc.Content,
+		MetaData: make(map[string]any, len(doc.MetaData)+2),
+	}
+	for k, v := range doc.MetaData {
+		copied.MetaData[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type fakeChatModel struct {
+	generate func(prompt string) (string, error)
+	mu       sync.Mutex
+	prompts  []string
+	running  atomic.Int32
+	peak     atomic.Int32
+}
+
+func (m *fakeChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	n := m.running.Add(1)
+	defer m.running.Add(-1)
+	for {
+		p := m.peak.Load()
+		if n <= p || m.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+
+	prompt := input[len(input)-1].Content
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.mu.Unlock()
+	content, err := m.generate(prompt)
+	if err != nil {
+		return nil, err
+	}
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func (m *fakeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+// paragraphSplitter splits documents by blank lines.
+type paragraphSplitter struct{}
+
+func (paragraphSplitter) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var ret []*schema.Document
+	for _, doc := range src {
+		for i, p := range strings.Split(doc.Content, "\n\n") {
+			ret = append(ret, &schema.Document{
+				ID:       doc.ID + "_" + string(rune('0'+i)),
+				Content:  p,
+				MetaData: map[string]any{"_parent_id": doc.ID},
+			})
+		}
+	}
+	return ret, nil
+}
+
+// chunkOf returns the chunk of a contextual prompt.
+func chunkOf(prompt string) string {
+	_, chunk, _ := strings.Cut(prompt, "<chunk>\n")
+	chunk, _, _ = strings.Cut(chunk, "\n</chunk>")
+	return chunk
+}
+
+func TestContextualizer(t *testing.T) {
+	ctx := context.Background()
+	docs := []*schema.Document{
+		{ID: "report", Content: "ACME annual report 2024\n\nRevenue grew by 3% in Q2.\n\nThe fail paragraph."},
+		{ID: "notes", Content: "Meeting notes"},
+	}
+
+	t.Run("with splitter", func(t *testing.T) {
+		cm := &fakeChatModel{generate: func(prompt string) (string, error) {
+			chunk := chunkOf(prompt)
+			if strings.Contains(chunk, "fail") {
+				return "", errors.New("model unavailable")
+			}
+			if strings.HasPrefix(prompt, "<document>\nMeeting notes\n</document>") {
+				return "Meeting notes.", nil
+			}
+			// the parent document is truncated to MaxDocumentLength
+			assert.True(t, strings.HasPrefix(prompt, "<document>\nACME annual report 2024\n\nReven\n</document>"), prompt)
+			return "From the ACME annual report 2024.", nil
+		}}
+		c, err := NewContextualizer(ctx, &ContextualConfig{
+			ChatModel:   cm,
+			Splitter:    paragraphSplitter{},
+			Concurrency: 2,
+			MaxDocumentLength: 30,
+		})
+		assert.NoError(t, err)
+
+		chunks, err := c.Transform(ctx, docs)
+		assert.NoError(t, err)
+		assert.Len(t, chunks, 4)
+		assert.Equal(t, "report_1", chunks[1].ID)
+		assert.Equal(t, "From the ACME annual report 2024.\n\nRevenue grew by 3% in Q2.", chunks[1].Content)
+		assert.Equal(t, "Revenue grew by 3% in Q2.", chunks[1].MetaData[MetaKeyOriginalContent])
+		assert.Equal(t, "From the ACME annual report 2024.", chunks[1].MetaData[MetaKeyContext])
+		assert.Equal(t, "report", chunks[1].MetaData["_parent_id"])
+		// the failed chunk is kept as is
+		assert.Equal(t, "The fail paragraph.", chunks[2].Content)
+		assert.NotContains(t, chunks[2].MetaData, MetaKeyContext)
+		assert.Equal(t, "Meeting notes.\n\nMeeting notes", chunks[3].Content)
+		assert.LessOrEqual(t, cm.peak.Load(), int32(2))
+	})
+
+	t.Run("with parent content", func(t *testing.T) {
+		cm := &fakeChatModel{generate: func(prompt string) (string, error) {
+			return "context of " + chunkOf(prompt), nil
+		}}
+		c, err := NewContextualizer(ctx, &ContextualConfig{
+			ChatModel: cm,
+			ParentContent: func(ctx context.Context, chunk *schema.Document) (string, error) {
+				return "parent of " + chunk.ID, nil
+			},
+			Prompt: "{document}|<chunk>\n{chunk}\n</chunk>",
+		})
+		assert.NoError(t, err)
+
+		// placeholders in the content are not replaced
+		chunks, err := c.Transform(ctx, []*schema.Document{{ID: "c", Content: "{document}"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "context of {document}\n\n{document}", chunks[0].Content)
+		assert.Equal(t, []string{"parent of c|<chunk>\n{document}\n</chunk>"}, cm.prompts)
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		cm := &fakeChatModel{generate: func(prompt string) (string, error) {
+			return "", errors.New("model unavailable")
+		}}
+		c, err := NewContextualizer(ctx, &ContextualConfig{
+			ChatModel: cm,
+			Splitter:  paragraphSplitter{},
+			OnDocumentError: func(ctx context.Context, doc *schema.Document, err error) error {
+				return err
+			},
+		})
+		assert.NoError(t, err)
+		_, err = c.Transform(ctx, docs)
+		assert.ErrorContains(t, err, "model unavailable")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewContextualizer(ctx, &ContextualConfig{ChatModel: &fakeChatModel{}})
+		assert.Error(t, err)
+		_, err = NewContextualizer(ctx, &ContextualConfig{Splitter: paragraphSplitter{}})
+		assert.Error(t, err)
+		_, err = NewContextualizer(ctx, &ContextualConfig{ChatModel: &fakeChatModel{}, Splitter: paragraphSplitter{}, Prompt: "{document}"})
+		assert.ErrorContains(t, err, "{chunk}")
+	})
+}
+
+func TestMetadataExtractor(t *testing.T) {
+	ctx := context.Background()
+	answers := map[string]string{
+		"fenced": "Here you are:\n```json\n" +
+			`{"title": "Fenced", "keywords": ["a", "b"], "entities": [], "questions": ["why?"], "extra": 1}` + "\n```",
+		"missing": `{"title": "Missing"}`,
+		"typed":   `{"title": 1, "keywords": [], "entities": [], "questions": []}`,
+		"invalid": `not json`,
+	}
+	cm := &fakeChatModel{generate: func(prompt string) (string, error) {
+		for k, v := range answers {
+			if strings.Contains(prompt, "<document>\n"+k+"\n</document>") {
+				return v, nil
+			}
+		}
+		return "", errors.New("unexpected prompt")
+	}}
+
+	var (
+		mu     sync.Mutex
+		failed = map[string]error{}
+	)
+	m, err := NewMetadataExtractor(ctx, &MetadataConfig{
+		ChatModel:   cm,
+		KeyPrefix:   "llm_",
+		Concurrency: 4,
+		OnDocumentError: func(ctx context.Context, doc *schema.Document, err error) error {
+			mu.Lock()
+			defer mu.Unlock()
+			failed[doc.ID] = err
+			return nil
+		},
+	})
+	assert.NoError(t, err)
+
+	src := []*schema.Document{
+		{ID: "fenced", Content: "fenced", MetaData: map[string]any{"source": "x"}},
+		{ID: "missing", Content: "missing"},
+		{ID: "typed", Content: "typed"},
+		{ID: "invalid", Content: "invalid"},
+	}
+	docs, err := m.Transform(ctx, src)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"source":        "x",
+		"llm_title":     "Fenced",
+		"llm_keywords":  []string{"a", "b"},
+		"llm_entities":  []string{},
+		"llm_questions": []string{"why?"},
+	}, docs[0].MetaData)
+	assert.Len(t, src[0].MetaData, 1)
+	for i := 1; i < len(src); i++ {
+		assert.Same(t, src[i], docs[i])
+		assert.Contains(t, failed, src[i].ID)
+	}
+	assert.ErrorContains(t, failed["missing"], `required property "entities"`)
+	assert.ErrorContains(t, failed["typed"], `property "title": expect string`)
+	assert.ErrorContains(t, failed["invalid"], "decode metadata failed")
+	assert.Contains(t, cm.prompts[0], `"description": "up to 3 questions the document answers"`)
+
+	t.Run("custom schema", func(t *testing.T) {
+		cm := &fakeChatModel{generate: func(prompt string) (string, error) {
+			return `{"year": 2024, "language": "en"}`, nil
+		}}
+		m, err := NewMetadataExtractor(ctx, &MetadataConfig{
+			ChatModel: cm,
+			Schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"year":     map[string]any{"type": "integer"},
+					"language": map[string]any{"type": "string"},
+					"author":   map[string]any{"type": "string"},
+				},
+				"required": []any{"year"},
+			},
+		})
+		assert.NoError(t, err)
+		docs, err := m.Transform(ctx, []*schema.Document{{ID: "1", Content: "doc"}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"year": 2024, "language": "en"}, docs[0].MetaData)
+
+		_, err = NewMetadataExtractor(ctx, &MetadataConfig{ChatModel: cm, Schema: map[string]any{"type": "string"}})
+		assert.Error(t, err)
+	})
+}
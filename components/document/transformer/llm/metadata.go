@@ -0,0 +1,301 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// DefaultMetadataSchema extracts a title, keywords, named entities and the questions a document answers,
+// written to the "title", "keywords", "entities" and "questions" metadata.
+var DefaultMetadataSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"title": map[string]any{
+			"type":        "string",
+			"description": "a short title of the document",
+		},
+		"keywords": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "up to 5 keywords of the document",
+		},
+		"entities": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "named entities mentioned in the document, such as people, organizations, places and products",
+		},
+		"questions": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "up to 3 questions the document answers",
+		},
+	},
+	"required": []string{"title", "keywords", "entities", "questions"},
+}
+
+const metadataSystemPrompt = "You extract structured metadata from documents. You answer with a single JSON object and nothing else."
+
+// DefaultMetadataPrompt is the default user prompt of the metadata extractor.
+const DefaultMetadataPrompt = `Extract the metadata of the document below as a JSON object following this JSON schema:
+{schema}
+
+<document>
+{document}
+</document>`
+
+type MetadataConfig struct {
+	// ChatModel extracts the metadata of every document, required.
+	ChatModel model.BaseChatModel
+	// ModelOptions are passed to every call of ChatModel, e.g. model.WithTemperature(0).
+	ModelOptions []model.Option
+
+	// Schema is the JSON schema of the metadata, an object whose properties are written to the document metadata.
+	// The answer of the model is checked against the types of the properties and the required properties,
+	// properties not in the schema are dropped. Defaults to DefaultMetadataSchema.
+	Schema map[string]any
+	// KeyPrefix is prepended to the property names to make the metadata keys, e.g. "llm_".
+	KeyPrefix string
+
+	// Prompt is the user prompt, {schema} is replaced by the JSON schema and {document} by the document.
+	// Defaults to DefaultMetadataPrompt.
+	Prompt string
+	// MaxDocumentLength truncates the document in the prompt to its first MaxDocumentLength runes, if set.
+	MaxDocumentLength int
+
+	// Concurrency is the number of documents processed in parallel, 1 by default.
+	Concurrency int
+	// OnDocumentError is called with the document when its metadata can not be extracted,
+	// including answers that are not valid JSON or do not match the schema.
+	// Returning nil keeps the document without metadata, returning an error aborts the whole transform.
+	// Defaults to logging the error and keeping the document.
+	OnDocumentError ErrorHandler
+}
+
+// NewMetadataExtractor creates a transformer extracting structured metadata from every document with a chat model.
+// The output documents are copies of the input ones, with the extracted properties added to their metadata.
+func NewMetadataExtractor(ctx context.Context, config *MetadataConfig) (document.Transformer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	s := config.Schema
+	if s == nil {
+		s = DefaultMetadataSchema
+	}
+	props, err := parseSchema(s)
+	if err != nil {
+		return nil, err
+	}
+	schemaJSON, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema failed: %w", err)
+	}
+
+	prompt := config.Prompt
+	if prompt == "" {
+		prompt = DefaultMetadataPrompt
+	}
+	if err = checkPrompt(prompt, "schema", "document"); err != nil {
+		return nil, err
+	}
+	r, err := newRunner("metadata extractor", config.ChatModel, config.ModelOptions, config.Concurrency, config.OnDocumentError)
+	if err != nil {
+		return nil, err
+	}
+	return &metadataExtractor{
+		runner:            r,
+		props:             props,
+		schema:            string(schemaJSON),
+		keyPrefix:         config.KeyPrefix,
+		prompt:            prompt,
+		maxDocumentLength: config.MaxDocumentLength,
+	}, nil
+}
+
+type metadataExtractor struct {
+	*runner
+	props             []*property
+	schema            string
+	keyPrefix         string
+	prompt            string
+	maxDocumentLength int
+}
+
+func (m *metadataExtractor) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, len(src))
+	err := m.run(ctx, src, func(ctx context.Context, i int, doc *schema.Document) error {
+		ret[i] = doc
+		text, err := m.generate(ctx, metadataSystemPrompt, formatPrompt(m.prompt, map[string]string{
+			"schema":   m.schema,
+			"document": truncate(doc.Content, m.maxDocumentLength),
+		}))
+		if err != nil {
+			return err
+		}
+		values, err := m.parse(text)
+		if err != nil {
+			return err
+		}
+
+		copied := copyDocument(doc)
+		for k, v := range values {
+			copied.MetaData[m.keyPrefix+k] = v
+		}
+		ret[i] = copied
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// parse decodes the answer of the model and checks it against the schema.
+func (m *metadataExtractor) parse(text string) (map[string]any, error) {
+	// models tend to wrap JSON in markdown code fences or to comment on it despite the prompt
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(text), &obj); err != nil {
+		return nil, fmt.Errorf("decode metadata failed: %w", err)
+	}
+
+	values := make(map[string]any, len(m.props))
+	for _, p := range m.props {
+		v, ok := obj[p.name]
+		if !ok || v == nil {
+			if p.required {
+				return nil, fmt.Errorf("metadata misses required property %q", p.name)
+			}
+			continue
+		}
+		v, err := p.convert(v)
+		if err != nil {
+			return nil, fmt.Errorf("metadata property %q: %w", p.name, err)
+		}
+		values[p.name] = v
+	}
+	return values, nil
+}
+
+func (m *metadataExtractor) GetType() string {
+	return "LLMMetadataExtractor"
+}
+
+// property is a top level property of the metadata schema.
+type property struct {
+	name      string
+	typ       string
+	itemsType string
+	required  bool
+}
+
+func parseSchema(s map[string]any) ([]*property, error) {
+	props, ok := s["properties"].(map[string]any)
+	if !ok || len(props) == 0 {
+		return nil, fmt.Errorf("schema must be an object with properties")
+	}
+	required := map[string]bool{}
+	switch r := s["required"].(type) {
+	case []string:
+		for _, name := range r {
+			required[name] = true
+		}
+	case []any:
+		for _, name := range r {
+			if n, ok := name.(string); ok {
+				required[n] = true
+			}
+		}
+	}
+
+	var ret []*property
+	for name, p := range props {
+		ps, _ := p.(map[string]any)
+		prop := &property{name: name, required: required[name]}
+		prop.typ, _ = ps["type"].(string)
+		if items, ok := ps["items"].(map[string]any); ok {
+			prop.itemsType, _ = items["type"].(string)
+		}
+		ret = append(ret, prop)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].name < ret[j].name
+	})
+	return ret, nil
+}
+
+// convert checks the type of a decoded JSON value, integers are converted to int and arrays of strings to []string.
+func (p *property) convert(v any) (any, error) {
+	if !matchType(p.typ, v) {
+		return nil, fmt.Errorf("expect %s, got %T", p.typ, v)
+	}
+	if p.typ == "integer" {
+		return int(v.(float64)), nil
+	}
+	arr, ok := v.([]any)
+	if !ok || p.itemsType == "" {
+		return v, nil
+	}
+	for _, item := range arr {
+		if !matchType(p.itemsType, item) {
+			return nil, fmt.Errorf("expect items of %s, got %T", p.itemsType, item)
+		}
+	}
+	if p.itemsType != "string" {
+		return v, nil
+	}
+	strs := make([]string, len(arr))
+	for i, item := range arr {
+		strs[i] = item.(string)
+	}
+	return strs, nil
+}
+
+func matchType(typ string, v any) bool {
+	switch typ {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == float64(int64(f))
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	default:
+		return true
+	}
+}
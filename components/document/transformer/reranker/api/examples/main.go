@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/reranker/api"
+)
+
+func main() {
+	ctx := context.Background()
+
+	reranker, err := api.NewReranker(ctx, &api.Config{
+		Provider: api.ProviderJina,
+		APIKey:   os.Getenv("JINA_API_KEY"),
+		Model:    "jina-reranker-v2-base-multilingual",
+		TopN:     2,
+	})
+	if err != nil {
+		log.Fatalf("NewReranker of api reranker failed, err=%v", err)
+	}
+
+	docs, err := reranker.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Paris is the capital and largest city of France."},
+		{ID: "2", Content: "Berlin is the capital of Germany."},
+		{ID: "3", Content: "The Eiffel Tower is located in Paris."},
+		{ID: "4", Content: "Bananas are rich in potassium."},
+	}, api.WithQuery("What is the capital of France?"))
+	if err != nil {
+		log.Fatalf("Transform of api reranker failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("%s %.4f %s\n", doc.ID, doc.Score(), doc.Content)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Provider is the rerank API called by the reranker.
+type Provider string
+
+const (
+	// ProviderCohere is the Cohere rerank API, https://docs.cohere.com/reference/rerank.
+	ProviderCohere Provider = "cohere"
+	// ProviderJina is the Jina rerank API, https://jina.ai/reranker.
+	ProviderJina Provider = "jina"
+	// ProviderVoyage is the Voyage AI rerank API, https://docs.voyageai.com/reference/reranker-api.
+	ProviderVoyage Provider = "voyage"
+	// ProviderTEI is the /rerank route of a Text Embeddings Inference server, https://github.com/huggingface/text-embeddings-inference.
+	// BaseURL is required.
+	ProviderTEI Provider = "tei"
+	// ProviderDashScope is the DashScope text rerank API, e.g. for gte-rerank models.
+	ProviderDashScope Provider = "dashscope"
+	// ProviderQianfan is the Qianfan v2 rerank API, e.g. for bce-reranker-base models.
+	ProviderQianfan Provider = "qianfan"
+)
+
+// result is the relevance score of the document at index in a request.
+type result struct {
+	index int
+	score float64
+}
+
+type apiSchema struct {
+	defaultURL    string
+	modelRequired bool
+	// buildRequest builds the request body, topN is 0 to score all documents.
+	buildRequest  func(model, query string, docs []string, topN int) any
+	parseResponse func(body []byte) ([]result, error)
+}
+
+var apiSchemas = map[Provider]*apiSchema{
+	ProviderCohere: {
+		defaultURL:    "https://api.cohere.com/v2/rerank",
+		modelRequired: true,
+		buildRequest:  buildRerankRequest,
+		parseResponse: parseRerankResponse,
+	},
+	ProviderJina: {
+		defaultURL:    "https://api.jina.ai/v1/rerank",
+		modelRequired: true,
+		buildRequest:  buildRerankRequest,
+		parseResponse: parseRerankResponse,
+	},
+	ProviderQianfan: {
+		defaultURL:    "https://qianfan.baidubce.com/v2/rerankers",
+		modelRequired: true,
+		buildRequest:  buildRerankRequest,
+		parseResponse: parseRerankResponse,
+	},
+	ProviderVoyage: {
+		defaultURL:    "https://api.voyageai.com/v1/rerank",
+		modelRequired: true,
+		buildRequest: func(model, query string, docs []string, topN int) any {
+			return &voyageRequest{Model: model, Query: query, Documents: docs, TopK: topN}
+		},
+		parseResponse: func(body []byte) ([]result, error) {
+			resp := &voyageResponse{}
+			if err := json.Unmarshal(body, resp); err != nil {
+				return nil, err
+			}
+			return toResults(resp.Data), nil
+		},
+	},
+	ProviderTEI: {
+		buildRequest: func(model, query string, docs []string, topN int) any {
+			return &teiRequest{Query: query, Texts: docs}
+		},
+		parseResponse: func(body []byte) ([]result, error) {
+			var resp []teiResult
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return nil, err
+			}
+			results := make([]result, len(resp))
+			for i, r := range resp {
+				results[i] = result{index: r.Index, score: r.Score}
+			}
+			return results, nil
+		},
+	},
+	ProviderDashScope: {
+		defaultURL:    "https://dashscope.aliyuncs.com/api/v1/services/rerank/text-rerank/text-rerank",
+		modelRequired: true,
+		buildRequest: func(model, query string, docs []string, topN int) any {
+			req := &dashScopeRequest{Model: model}
+			req.Input.Query = query
+			req.Input.Documents = docs
+			req.Parameters.TopN = topN
+			return req
+		},
+		parseResponse: func(body []byte) ([]result, error) {
+			resp := &dashScopeResponse{}
+			if err := json.Unmarshal(body, resp); err != nil {
+				return nil, err
+			}
+			return toResults(resp.Output.Results), nil
+		},
+	},
+}
+
+// rerankRequest is the request of the Cohere rerank API, also accepted by Jina and Qianfan.
+type rerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+type rerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+type rerankResponse struct {
+	Results []rerankResult `json:"results"`
+}
+
+func buildRerankRequest(model, query string, docs []string, topN int) any {
+	return &rerankRequest{Model: model, Query: query, Documents: docs, TopN: topN}
+}
+
+func parseRerankResponse(body []byte) ([]result, error) {
+	resp := &rerankResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, err
+	}
+	return toResults(resp.Results), nil
+}
+
+type voyageRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopK      int      `json:"top_k,omitempty"`
+}
+
+type voyageResponse struct {
+	Data []rerankResult `json:"data"`
+}
+
+type teiRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+}
+
+type teiResult struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+type dashScopeRequest struct {
+	Model string `json:"model"`
+	Input struct {
+		Query     string   `json:"query"`
+		Documents []string `json:"documents"`
+	} `json:"input"`
+	Parameters struct {
+		TopN int `json:"top_n,omitempty"`
+	} `json:"parameters"`
+}
+
+type dashScopeResponse struct {
+	Output struct {
+		Results []rerankResult `json:"results"`
+	} `json:"output"`
+}
+
+func toResults(rs []rerankResult) []result {
+	results := make([]result, len(rs))
+	for i, r := range rs {
+		results[i] = result{index: r.Index, score: r.RelevanceScore}
+	}
+	return results
+}
+
+// errorMessage extracts the error message of a failed request from the usual error bodies of the providers,
+// {"message": "..."}, {"error": "..."}, {"error": {"message": "..."}} or {"detail": "..."}.
+func errorMessage(body []byte) string {
+	var resp struct {
+		Message string          `json:"message"`
+		Detail  any             `json:"detail"`
+		Error   json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return ""
+	}
+	if resp.Message != "" {
+		return resp.Message
+	}
+	if len(resp.Error) > 0 {
+		var msg string
+		if err := json.Unmarshal(resp.Error, &msg); err == nil {
+			return msg
+		}
+		var obj struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(resp.Error, &obj); err == nil && obj.Message != "" {
+			return obj.Message
+		}
+	}
+	if resp.Detail != nil {
+		return fmt.Sprint(resp.Detail)
+	}
+	return ""
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultConcurrency = 1
+
+type Config struct {
+	// Provider selects the request and response schema of the rerank API, required.
+	Provider Provider
+	// BaseURL is the URL of the rerank endpoint, e.g. "http://localhost:8080/rerank" for TEI.
+	// Defaults to the public endpoint of the provider, required for ProviderTEI.
+	BaseURL string
+	// APIKey is sent as a bearer token if set.
+	APIKey string
+	// Headers are added to every request, e.g. for a gateway in front of the API.
+	Headers map[string]string
+	// Model is the rerank model, e.g. "rerank-v3.5", "jina-reranker-v2-base-multilingual", "gte-rerank-v2".
+	// Required except for ProviderTEI, which serves a single model.
+	Model string
+
+	// TopN is the number of documents returned, the best ones first. All documents are returned if zero.
+	// Can be overridden per call by WithTopN.
+	TopN int
+	// ScoreThreshold drops the documents scoring below it if set.
+	ScoreThreshold *float64
+	// BatchSize is the maximum number of documents sent in one request, all documents are sent at once if zero.
+	// Documents of all batches are ranked together, the scores of a cross-encoder being comparable across requests.
+	BatchSize int
+	// Concurrency is the number of requests sent in parallel when documents are batched, 1 by default.
+	Concurrency int
+
+	// ScoreFieldKey specifies the key in metadata the relevance score is written to. Use WithScore() method by default.
+	ScoreFieldKey *string
+
+	// Timeout specifies the http request timeout.
+	// If HTTPClient is set, Timeout will not be used.
+	Timeout time.Duration
+	// HTTPClient specifies the client to send HTTP requests.
+	// If HTTPClient is set, Timeout will not be used.
+	// Optional. Default &http.Client{Timeout: Timeout}
+	HTTPClient *http.Client
+}
+
+// NewReranker creates a transformer ranking documents by their relevance to a query with a rerank model,
+// usually a cross-encoder, served by a rerank API.
+//
+// The query is given per call by WithQuery. The documents are returned sorted by relevance, best first,
+// as copies of the input ones carrying the relevance score.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	spec, ok := apiSchemas[config.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown rerank provider: %q", config.Provider)
+	}
+	url := config.BaseURL
+	if url == "" {
+		url = spec.defaultURL
+	}
+	if url == "" {
+		return nil, fmt.Errorf("base url is required for provider %s", config.Provider)
+	}
+	if spec.modelRequired && config.Model == "" {
+		return nil, fmt.Errorf("model is required for provider %s", config.Provider)
+	}
+	if config.TopN < 0 || config.BatchSize < 0 || config.Concurrency < 0 {
+		return nil, errors.New("top n, batch size and concurrency must not be negative")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: config.Timeout}
+	}
+	concurrency := config.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultConcurrency
+	}
+
+	r := &reranker{
+		schema:         spec,
+		url:            url,
+		apiKey:         config.APIKey,
+		headers:        config.Headers,
+		model:          config.Model,
+		topN:           config.TopN,
+		scoreThreshold: config.ScoreThreshold,
+		batchSize:      config.BatchSize,
+		concurrency:    concurrency,
+		client:         client,
+	}
+	if config.ScoreFieldKey == nil {
+		r.setScore = func(doc *schema.Document, score float64) {
+			doc.WithScore(score)
+		}
+	} else {
+		key := *config.ScoreFieldKey
+		r.setScore = func(doc *schema.Document, score float64) {
+			doc.MetaData[key] = score
+		}
+	}
+	return r, nil
+}
+
+type reranker struct {
+	schema         *apiSchema
+	url            string
+	apiKey         string
+	headers        map[string]string
+	model          string
+	topN           int
+	scoreThreshold *float64
+	batchSize      int
+	concurrency    int
+	client         *http.Client
+	setScore       func(doc *schema.Document, score float64)
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{TopN: r.topN}, opts...)
+	if o.Query == "" {
+		return nil, errors.New("query is required, set it with WithQuery")
+	}
+	if len(src) == 0 {
+		return []*schema.Document{}, nil
+	}
+
+	texts := make([]string, len(src))
+	for i, doc := range src {
+		texts[i] = doc.Content
+	}
+	results, err := r.rerank(ctx, o.Query, texts, o.TopN)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	ret := make([]*schema.Document, 0, len(results))
+	for _, res := range results {
+		if r.scoreThreshold != nil && res.score < *r.scoreThreshold {
+			break
+		}
+		if o.TopN > 0 && len(ret) == o.TopN {
+			break
+		}
+		doc := src[res.index]
+		copied := &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: make(map[string]any, len(doc.MetaData)+1),
+		}
+		for k, v := range doc.MetaData {
+			copied.MetaData[k] = v
+		}
+		r.setScore(copied, res.score)
+		ret = append(ret, copied)
+	}
+	return ret, nil
+}
+
+// rerank scores the texts in batches, the top n of a single batch is left to the API.
+func (r *reranker) rerank(ctx context.Context, query string, texts []string, topN int) ([]result, error) {
+	batchSize := r.batchSize
+	if batchSize == 0 || batchSize > len(texts) {
+		batchSize = len(texts)
+	}
+	if batchSize < len(texts) || topN >= len(texts) {
+		topN = 0
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results = make([][]result, (len(texts)+batchSize-1)/batchSize)
+		sem     = make(chan struct{}, r.concurrency)
+		wg      sync.WaitGroup
+		once    sync.Once
+		reqErr  error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			reqErr = err
+			cancel()
+		})
+	}
+
+	for start := 0; start < len(texts); start += batchSize {
+		end := start + batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := r.do(ctx, query, texts[start:end], topN)
+			if err != nil {
+				fail(err)
+				return
+			}
+			for i := range res {
+				if res[i].index < 0 || res[i].index >= end-start {
+					fail(fmt.Errorf("rerank API returns index %d for %d documents", res[i].index, end-start))
+					return
+				}
+				res[i].index += start
+			}
+			results[start/batchSize] = res
+		}(start, end)
+	}
+	wg.Wait()
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var all []result
+	for _, res := range results {
+		all = append(all, res...)
+	}
+	return all, nil
+}
+
+func (r *reranker) do(ctx context.Context, query string, texts []string, topN int) ([]result, error) {
+	body, err := json.Marshal(r.schema.buildRequest(r.model, query, texts, topN))
+	if err != nil {
+		return nil, fmt.Errorf("marshal rerank request failed: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create rerank request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read rerank response failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if msg := errorMessage(respBody); msg != "" {
+			return nil, fmt.Errorf("rerank request failed with status code %d: %s", resp.StatusCode, msg)
+		}
+		return nil, fmt.Errorf("rerank request failed with status code: %d", resp.StatusCode)
+	}
+
+	results, err := r.schema.parseResponse(respBody)
+	if err != nil {
+		return nil, fmt.Errorf("decode rerank response failed: %w", err)
+	}
+	return results, nil
+}
+
+func (r *reranker) GetType() string {
+	return "APIReranker"
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// relevance scores a document by the share of the query words it contains.
+func relevance(query, doc string) float64 {
+	words := strings.Fields(query)
+	n := 0
+	for _, w := range words {
+		if strings.Contains(doc, w) {
+			n++
+		}
+	}
+	return float64(n) / float64(len(words))
+}
+
+type fakeRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	Texts     []string `json:"texts"`
+	TopN      int      `json:"top_n"`
+	TopK      int      `json:"top_k"`
+	Input     *struct {
+		Query     string   `json:"query"`
+		Documents []string `json:"documents"`
+	} `json:"input"`
+	Parameters *struct {
+		TopN int `json:"top_n"`
+	} `json:"parameters"`
+}
+
+type fakeServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*fakeRequest
+	calls    atomic.Int32
+}
+
+// newFakeServer serves the rerank API of the provider, returning the results best first as the real APIs do.
+func newFakeServer(t *testing.T, provider Provider) *fakeServer {
+	s := &fakeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls.Add(1)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		req := &fakeRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		if req.Query == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "invalid query"}}`))
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+
+		query, docs, topN := req.Query, req.Documents, req.TopN
+		switch provider {
+		case ProviderTEI:
+			docs = req.Texts
+		case ProviderVoyage:
+			topN = req.TopK
+		case ProviderDashScope:
+			query, docs, topN = req.Input.Query, req.Input.Documents, req.Parameters.TopN
+		}
+
+		type item struct {
+			Index          int     `json:"index"`
+			RelevanceScore float64 `json:"relevance_score,omitempty"`
+			Score          float64 `json:"score,omitempty"`
+		}
+		var items []item
+		for i, doc := range docs {
+			if provider == ProviderTEI {
+				items = append(items, item{Index: i, Score: relevance(query, doc)})
+			} else {
+				items = append(items, item{Index: i, RelevanceScore: relevance(query, doc)})
+			}
+		}
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if items[j].RelevanceScore+items[j].Score > items[i].RelevanceScore+items[i].Score {
+					items[i], items[j] = items[j], items[i]
+				}
+			}
+		}
+		if topN > 0 && topN < len(items) {
+			items = items[:topN]
+		}
+
+		var resp any
+		switch provider {
+		case ProviderTEI:
+			resp = items
+		case ProviderVoyage:
+			resp = map[string]any{"data": items}
+		case ProviderDashScope:
+			resp = map[string]any{"output": map[string]any{"results": items}}
+		default:
+			resp = map[string]any{"results": items}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	return s
+}
+
+var testDocs = []*schema.Document{
+	{ID: "0", Content: "the capital of france is paris", MetaData: map[string]any{"source": "a"}},
+	{ID: "1", Content: "paris is a city"},
+	{ID: "2", Content: "berlin is the capital of germany"},
+	{ID: "3", Content: "bananas are yellow"},
+	{ID: "4", Content: "the capital of france"},
+}
+
+func docIDs(docs []*schema.Document) []string {
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestReranker_Providers(t *testing.T) {
+	ctx := context.Background()
+	for _, provider := range []Provider{ProviderCohere, ProviderJina, ProviderVoyage, ProviderTEI, ProviderDashScope, ProviderQianfan} {
+		t.Run(string(provider), func(t *testing.T) {
+			server := newFakeServer(t, provider)
+			defer server.Close()
+
+			r, err := NewReranker(ctx, &Config{
+				Provider: provider,
+				BaseURL:  server.URL,
+				APIKey:   "key",
+				Model:    "rerank-model",
+				TopN:     3,
+			})
+			assert.NoError(t, err)
+
+			docs, err := r.Transform(ctx, testDocs, WithQuery("capital of france paris"))
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"0", "4", "2"}, docIDs(docs))
+			assert.Equal(t, []float64{1, 0.75, 0.5}, []float64{docs[0].Score(), docs[1].Score(), docs[2].Score()})
+			assert.Equal(t, "a", docs[0].MetaData["source"])
+			// input documents are left untouched
+			assert.Equal(t, 0.0, testDocs[0].Score())
+
+			assert.Len(t, server.requests, 1)
+			if provider != ProviderTEI {
+				assert.Equal(t, 3, server.requests[0].TopN+server.requests[0].TopK+dashScopeTopN(server.requests[0]))
+			}
+		})
+	}
+}
+
+func dashScopeTopN(req *fakeRequest) int {
+	if req.Parameters == nil {
+		return 0
+	}
+	return req.Parameters.TopN
+}
+
+func TestReranker_Batching(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t, ProviderCohere)
+	defer server.Close()
+
+	threshold := 0.5
+	scoreKey := "relevance"
+	r, err := NewReranker(ctx, &Config{
+		Provider:       ProviderCohere,
+		BaseURL:        server.URL,
+		APIKey:         "key",
+		Model:          "rerank-model",
+		BatchSize:      2,
+		Concurrency:    2,
+		ScoreThreshold: &threshold,
+		ScoreFieldKey:  &scoreKey,
+	})
+	assert.NoError(t, err)
+
+	docs, err := r.Transform(ctx, testDocs, WithQuery("capital of france paris"), WithTopN(10))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "4", "2"}, docIDs(docs))
+	assert.Equal(t, 0.75, docs[1].MetaData[scoreKey])
+	assert.Equal(t, int32(3), server.calls.Load())
+	for _, req := range server.requests {
+		// all scores of every batch are needed to rank the documents together
+		assert.Equal(t, 0, req.TopN)
+	}
+}
+
+func TestReranker_Errors(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t, ProviderJina)
+	defer server.Close()
+
+	_, err := NewReranker(ctx, &Config{Provider: ProviderTEI})
+	assert.ErrorContains(t, err, "base url is required")
+	_, err = NewReranker(ctx, &Config{Provider: ProviderCohere})
+	assert.ErrorContains(t, err, "model is required")
+	_, err = NewReranker(ctx, &Config{Provider: "unknown"})
+	assert.Error(t, err)
+
+	r, err := NewReranker(ctx, &Config{Provider: ProviderJina, BaseURL: server.URL, APIKey: "key", Model: "m", BatchSize: 2})
+	assert.NoError(t, err)
+
+	_, err = r.Transform(ctx, testDocs)
+	assert.ErrorContains(t, err, "query is required")
+
+	_, err = r.Transform(ctx, testDocs, WithQuery("fail"))
+	assert.ErrorContains(t, err, "status code 400: invalid query")
+
+	docs, err := r.Transform(ctx, nil, WithQuery("q"))
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results": [{"index": 5, "relevance_score": 1}]}`))
+	}))
+	defer bad.Close()
+	r, err = NewReranker(ctx, &Config{Provider: ProviderJina, BaseURL: bad.URL, Model: "m"})
+	assert.NoError(t, err)
+	_, err = r.Transform(ctx, testDocs[:2], WithQuery("q"))
+	assert.ErrorContains(t, err, "returns index 5 for 2 documents")
+}
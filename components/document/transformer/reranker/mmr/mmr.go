@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultLambda = 0.5
+
+type Config struct {
+	// Lambda trades relevance against diversity, from 1 selecting by relevance only to 0 selecting by diversity only.
+	// Defaults to 0.5, can be overridden per call by WithLambda.
+	Lambda *float64
+	// TopK is the number of documents selected, all documents are reordered if zero.
+	// Can be overridden per call by WithTopK.
+	TopK int
+	// Embedding embeds the query given by WithQuery and the documents without DenseVector.
+	// Optional if the documents carry their vectors, see the ReturnVectors config of the qdrant and milvus retrievers.
+	Embedding embedding.Embedder
+	// ScoreFieldKey specifies the key in metadata that stores the relevance of the document,
+	// used when no query is given. Use Score() method by default.
+	// The relevance must be a similarity comparable to cosine similarity, as the cosine or dot product scores of vector stores.
+	ScoreFieldKey *string
+}
+
+// NewReranker creates a maximal marginal relevance (MMR) reranker, https://www.cs.cmu.edu/~jgc/publication/The_Use_MMR_Diversity_Based_LTMIR_1998.pdf.
+//
+// Documents are selected one at a time, picking the document maximizing
+// lambda * relevance - (1 - lambda) * max similarity to the documents already selected,
+// so that near-duplicates of a selected document are pushed down the list.
+// Similarities are the cosine similarities of the document vectors, the relevance is the similarity
+// to the query vector given by WithQueryVector or embedded from WithQuery, or the score of the document.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	lambda := defaultLambda
+	if config.Lambda != nil {
+		lambda = *config.Lambda
+	}
+	if lambda < 0 || lambda > 1 {
+		return nil, fmt.Errorf("lambda must be in [0, 1], got %v", lambda)
+	}
+	if config.TopK < 0 {
+		return nil, fmt.Errorf("top k must not be negative, got %d", config.TopK)
+	}
+	return &reranker{
+		lambda:      lambda,
+		topK:        config.TopK,
+		embedding:   config.Embedding,
+		scoreGetter: newScoreGetter(config.ScoreFieldKey),
+	}, nil
+}
+
+func newScoreGetter(scoreFieldKey *string) func(doc *schema.Document) float64 {
+	if scoreFieldKey == nil {
+		return func(doc *schema.Document) float64 {
+			return doc.Score()
+		}
+	}
+	key := *scoreFieldKey
+	return func(doc *schema.Document) float64 {
+		v, _ := doc.MetaData[key].(float64)
+		return v
+	}
+}
+
+type reranker struct {
+	lambda      float64
+	topK        int
+	embedding   embedding.Embedder
+	scoreGetter func(doc *schema.Document) float64
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{Lambda: r.lambda, TopK: r.topK}, opts...)
+	if o.Lambda < 0 || o.Lambda > 1 {
+		return nil, fmt.Errorf("lambda must be in [0, 1], got %v", o.Lambda)
+	}
+	if len(src) == 0 {
+		return []*schema.Document{}, nil
+	}
+
+	vectors, queryVector, err := r.vectors(ctx, src, o)
+	if err != nil {
+		return nil, err
+	}
+
+	relevance := make([]float64, len(src))
+	for i, doc := range src {
+		if queryVector != nil {
+			if relevance[i], err = cosine(vectors[i], queryVector); err != nil {
+				return nil, fmt.Errorf("compare document [%s] with query failed: %w", doc.ID, err)
+			}
+		} else {
+			relevance[i] = r.scoreGetter(doc)
+		}
+	}
+
+	topK := o.TopK
+	if topK == 0 || topK > len(src) {
+		topK = len(src)
+	}
+
+	var (
+		selected = make([]*schema.Document, 0, topK)
+		// maxSim is the max similarity of every candidate to the selected documents
+		maxSim = make([]float64, len(src))
+		picked = make([]bool, len(src))
+	)
+	for len(selected) < topK {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range src {
+			if picked[i] {
+				continue
+			}
+			score := o.Lambda * relevance[i]
+			if len(selected) > 0 {
+				score -= (1 - o.Lambda) * maxSim[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		picked[best] = true
+		selected = append(selected, src[best])
+		for i := range src {
+			if picked[i] {
+				continue
+			}
+			sim, err := cosine(vectors[i], vectors[best])
+			if err != nil {
+				return nil, fmt.Errorf("compare document [%s] with [%s] failed: %w", src[i].ID, src[best].ID, err)
+			}
+			if len(selected) == 1 || sim > maxSim[i] {
+				maxSim[i] = sim
+			}
+		}
+	}
+	return selected, nil
+}
+
+// vectors returns the vectors of the documents and of the query, embedding the missing ones in a single call.
+func (r *reranker) vectors(ctx context.Context, src []*schema.Document, o *options) ([][]float64, []float64, error) {
+	var (
+		vectors = make([][]float64, len(src))
+		texts   []string
+		missing []int
+	)
+	for i, doc := range src {
+		if v := doc.DenseVector(); len(v) > 0 {
+			vectors[i] = v
+			continue
+		}
+		texts = append(texts, doc.Content)
+		missing = append(missing, i)
+	}
+
+	queryVector := o.QueryVector
+	embedQuery := queryVector == nil && o.Query != ""
+	if embedQuery {
+		texts = append(texts, o.Query)
+	}
+	if len(texts) == 0 {
+		return vectors, queryVector, nil
+	}
+	if r.embedding == nil {
+		if len(missing) > 0 {
+			return nil, nil, fmt.Errorf("document [%s] has no vector and no embedding is configured", src[missing[0]].ID)
+		}
+		return nil, nil, errors.New("query needs to be embedded but no embedding is configured, use WithQueryVector instead")
+	}
+
+	embedded, err := r.embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("embed strings failed: %w", err)
+	}
+	if len(embedded) != len(texts) {
+		return nil, nil, fmt.Errorf("embedding returns %d vectors for %d texts", len(embedded), len(texts))
+	}
+	for j, i := range missing {
+		vectors[i] = embedded[j]
+	}
+	if embedQuery {
+		queryVector = embedded[len(embedded)-1]
+	}
+	return vectors, queryVector, nil
+}
+
+func (r *reranker) GetType() string {
+	return "MMRReranker"
+}
+
+func cosine(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("vector dimensions differ, %d and %d", len(a), len(b))
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0, nil
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb)), nil
+}
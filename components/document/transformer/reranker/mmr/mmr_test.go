@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+type mockEmbedding struct {
+	vectors map[string][]float64
+	calls   [][]string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls = append(m.calls, texts)
+	ret := make([][]float64, len(texts))
+	for i, text := range texts {
+		v, ok := m.vectors[text]
+		if !ok {
+			return nil, errors.New("unknown text " + text)
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+func testDocs() []*schema.Document {
+	return []*schema.Document{
+		(&schema.Document{ID: "a", Content: "a"}).WithDenseVector([]float64{0.9, 0.1}).WithScore(0.99),
+		(&schema.Document{ID: "a2", Content: "a2"}).WithDenseVector([]float64{0.9, 0.12}).WithScore(0.98),
+		(&schema.Document{ID: "b", Content: "b"}).WithDenseVector([]float64{0.5, 0.5}).WithScore(0.7),
+		(&schema.Document{ID: "c", Content: "c"}).WithDenseVector([]float64{0, 1}).WithScore(0.1),
+	}
+}
+
+func ids(docs []*schema.Document) []string {
+	var ret []string
+	for _, doc := range docs {
+		ret = append(ret, doc.ID)
+	}
+	return ret
+}
+
+func TestReranker(t *testing.T) {
+	ctx := context.Background()
+	lambda := 0.3
+	r, err := NewReranker(ctx, &Config{Lambda: &lambda, TopK: 3})
+	assert.NoError(t, err)
+
+	t.Run("query vector", func(t *testing.T) {
+		docs, err := r.Transform(ctx, testDocs(), WithQueryVector([]float64{1, 0}))
+		assert.NoError(t, err)
+		// the near-duplicate of a is pushed down, the least relevant but most diverse c comes up
+		assert.Equal(t, []string{"a", "c", "b"}, ids(docs))
+
+		docs, err = r.Transform(ctx, testDocs(), WithQueryVector([]float64{1, 0}), WithLambda(1), WithTopK(0))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "a2", "b", "c"}, ids(docs))
+	})
+
+	t.Run("scores", func(t *testing.T) {
+		docs, err := r.Transform(ctx, testDocs(), WithTopK(2))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "c"}, ids(docs))
+	})
+
+	t.Run("embedding", func(t *testing.T) {
+		emb := &mockEmbedding{vectors: map[string][]float64{
+			"query": {1, 0},
+			"a2":    {0.9, 0.12},
+		}}
+		r, err := NewReranker(ctx, &Config{Lambda: &lambda, Embedding: emb})
+		assert.NoError(t, err)
+
+		src := testDocs()
+		src[1].MetaData = nil
+		docs, err := r.Transform(ctx, src, WithQuery("query"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "c", "b", "a2"}, ids(docs))
+		// missing vectors and the query are embedded at once
+		assert.Equal(t, [][]string{{"a2", "query"}}, emb.calls)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		src := testDocs()
+		src[0].MetaData = nil
+		_, err := r.Transform(ctx, src)
+		assert.ErrorContains(t, err, "document [a] has no vector")
+
+		_, err = r.Transform(ctx, testDocs(), WithQuery("query"))
+		assert.ErrorContains(t, err, "no embedding is configured")
+
+		_, err = r.Transform(ctx, testDocs(), WithQueryVector([]float64{1, 0, 0}))
+		assert.ErrorContains(t, err, "vector dimensions differ")
+
+		docs, err := r.Transform(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, docs)
+
+		invalid := 2.0
+		_, err = NewReranker(ctx, &Config{Lambda: &invalid})
+		assert.Error(t, err)
+	})
+}
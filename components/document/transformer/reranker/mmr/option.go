@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"github.com/cloudwego/eino/components/document"
+)
+
+type options struct {
+	Query       string
+	QueryVector []float64
+	Lambda      float64
+	TopK        int
+}
+
+// WithQuery sets the query the relevance of the documents is measured against, embedded by Config.Embedding.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.Query = query
+	})
+}
+
+// WithQueryVector sets the vector of the query, e.g. the one the documents were retrieved with, saving its embedding.
+func WithQueryVector(vector []float64) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.QueryVector = vector
+	})
+}
+
+// WithLambda overrides Config.Lambda for one call.
+func WithLambda(lambda float64) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.Lambda = lambda
+	})
+}
+
+// WithTopK overrides Config.TopK for one call.
+func WithTopK(topK int) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.TopK = topK
+	})
+}
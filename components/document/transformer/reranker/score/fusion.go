@@ -0,0 +1,340 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package score
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyRetriever is the name of the retriever a document was returned by, see TagDocuments.
+	MetaKeyRetriever = "_retriever"
+	// MetaKeyRank is the 1-based rank of a document in the results of its retriever, see TagDocuments.
+	MetaKeyRank = "_rank"
+	// MetaKeyFusionRanks is set by the fusion transformer to the rank of the document in the results of every retriever
+	// that returned it, map[string]int keyed by retriever name.
+	MetaKeyFusionRanks = "_fusion_ranks"
+)
+
+// FusionMethod is the way the results of several retrievers are combined.
+type FusionMethod string
+
+const (
+	// FusionRRF is reciprocal rank fusion, a document scores sum(weight / (k + rank)) over the retrievers returning it.
+	// Only ranks are used, so retrievers with incomparable scores (e.g. BM25 and cosine similarity) can be fused.
+	FusionRRF FusionMethod = "rrf"
+	// FusionMinMax scales the scores of every retriever to [0, 1] with min-max normalization,
+	// a document scores the weighted sum of its normalized scores.
+	FusionMinMax FusionMethod = "min_max"
+	// FusionZScore standardizes the scores of every retriever to their z-score,
+	// a document scores the weighted sum of its standardized scores.
+	FusionZScore FusionMethod = "z_score"
+)
+
+// DedupeKey identifies the documents returned by several retrievers as the same one.
+type DedupeKey string
+
+const (
+	// DedupeByID merges documents with the same ID, documents without ID are merged by content.
+	DedupeByID DedupeKey = "id"
+	// DedupeByContent merges documents with the same content, whatever their IDs,
+	// e.g. when the same chunks are indexed with different IDs in several stores.
+	DedupeByContent DedupeKey = "content"
+)
+
+const defaultRRFK = 60
+
+type FusionConfig struct {
+	// Method of fusion, FusionRRF by default.
+	Method FusionMethod
+	// RRFK is the k constant of reciprocal rank fusion, 60 by default. A larger k flattens the gap between top ranks.
+	RRFK float64
+	// Weights of the retrievers keyed by retriever name, retrievers not listed weigh 1.
+	Weights map[string]float64
+	// ScoreFieldKey specifies the key in metadata that stores the document score,
+	// both to read the retriever scores and to write the fused score. Use Score() and WithScore() methods by default.
+	ScoreFieldKey *string
+	// DedupeBy selects how duplicates are detected, DedupeByID by default.
+	DedupeBy DedupeKey
+	// TopK limits the number of documents returned, all documents are returned if zero.
+	TopK int
+}
+
+// TagDocuments marks docs as the results of the named retriever, ranked in the given order,
+// so that they can be fused with the results of other retrievers. docs are modified in place and returned.
+func TagDocuments(retriever string, docs []*schema.Document) []*schema.Document {
+	for i, doc := range docs {
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+		doc.MetaData[MetaKeyRetriever] = retriever
+		doc.MetaData[MetaKeyRank] = i + 1
+	}
+	return docs
+}
+
+// NewFusion creates a transformer merging the results of several retrievers, e.g. the keyword and vector searches
+// of a hybrid search, into one list sorted by fused score.
+//
+// The input documents are grouped by their MetaKeyRetriever metadata and ranked by their MetaKeyRank metadata,
+// see TagDocuments. Documents without rank are ranked by their order among the documents of the same retriever,
+// documents without retriever belong to the unnamed retriever "".
+//
+// Duplicates are merged into the first of them, the output documents are copies of the input ones carrying the fused score
+// and MetaKeyFusionRanks in place of MetaKeyRetriever and MetaKeyRank. Chain with NewReranker to reorder the fused documents for the LLM context.
+func NewFusion(ctx context.Context, config *FusionConfig) (document.Transformer, error) {
+	if config == nil {
+		config = &FusionConfig{}
+	}
+	f := &fusion{
+		method:   config.Method,
+		k:        config.RRFK,
+		weights:  config.Weights,
+		dedupeBy: config.DedupeBy,
+		topK:     config.TopK,
+		getter:   newScoreGetter(config.ScoreFieldKey),
+	}
+	if f.method == "" {
+		f.method = FusionRRF
+	}
+	if f.k == 0 {
+		f.k = defaultRRFK
+	}
+	if f.dedupeBy == "" {
+		f.dedupeBy = DedupeByID
+	}
+
+	switch f.method {
+	case FusionRRF, FusionMinMax, FusionZScore:
+	default:
+		return nil, fmt.Errorf("unknown fusion method: %s", f.method)
+	}
+	switch f.dedupeBy {
+	case DedupeByID, DedupeByContent:
+	default:
+		return nil, fmt.Errorf("unknown dedupe key: %s", f.dedupeBy)
+	}
+	if f.k < 0 {
+		return nil, fmt.Errorf("rrf k must not be negative, got %v", f.k)
+	}
+	if f.topK < 0 {
+		return nil, fmt.Errorf("top k must not be negative, got %d", f.topK)
+	}
+
+	if config.ScoreFieldKey == nil {
+		f.setter = func(doc *schema.Document, score float64) {
+			doc.WithScore(score)
+		}
+	} else {
+		key := *config.ScoreFieldKey
+		f.setter = func(doc *schema.Document, score float64) {
+			doc.MetaData[key] = score
+		}
+	}
+	return f, nil
+}
+
+type fusion struct {
+	method   FusionMethod
+	k        float64
+	weights  map[string]float64
+	dedupeBy DedupeKey
+	topK     int
+	getter   func(doc *schema.Document) float64
+	setter   func(doc *schema.Document, score float64)
+}
+
+type fusedDocument struct {
+	doc   *schema.Document
+	score float64
+	ranks map[string]int
+	// values are the normalized scores of the document by retriever, unused by FusionRRF
+	values map[string]float64
+}
+
+func (f *fusion) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var (
+		order  []string
+		groups = map[string][]*schema.Document{}
+	)
+	for _, doc := range src {
+		name := retrieverOf(doc)
+		if _, ok := groups[name]; !ok {
+			order = append(order, name)
+		}
+		groups[name] = append(groups[name], doc)
+	}
+
+	var (
+		fused []*fusedDocument
+		byKey = map[string]*fusedDocument{}
+	)
+	for _, name := range order {
+		docs := groups[name]
+		values := f.values(docs)
+		for i, doc := range docs {
+			key := f.dedupeKey(doc)
+			fd, ok := byKey[key]
+			if !ok {
+				fd = &fusedDocument{doc: doc, ranks: map[string]int{}, values: map[string]float64{}}
+				byKey[key] = fd
+				fused = append(fused, fd)
+			}
+			// duplicates within the results of one retriever only count once, at their best rank
+			rank := rankOf(doc, i)
+			if prev, ok := fd.ranks[name]; ok && prev <= rank {
+				continue
+			}
+			fd.ranks[name] = rank
+			if values != nil {
+				fd.values[name] = values[i]
+			}
+		}
+	}
+
+	for _, fd := range fused {
+		for _, name := range order {
+			rank, ok := fd.ranks[name]
+			if !ok {
+				continue
+			}
+			weight := 1.0
+			if w, ok := f.weights[name]; ok {
+				weight = w
+			}
+			if f.method == FusionRRF {
+				fd.score += weight / (f.k + float64(rank))
+			} else {
+				fd.score += weight * fd.values[name]
+			}
+		}
+	}
+
+	sort.SliceStable(fused, func(i, j int) bool {
+		return fused[i].score > fused[j].score
+	})
+	if f.topK > 0 && len(fused) > f.topK {
+		fused = fused[:f.topK]
+	}
+
+	ret := make([]*schema.Document, 0, len(fused))
+	for _, fd := range fused {
+		doc := &schema.Document{
+			ID:       fd.doc.ID,
+			Content:  fd.doc.Content,
+			MetaData: make(map[string]any, len(fd.doc.MetaData)+2),
+		}
+		for k, v := range fd.doc.MetaData {
+			doc.MetaData[k] = v
+		}
+		// the retriever and rank of the first duplicate are superseded by the ranks of all retrievers
+		delete(doc.MetaData, MetaKeyRetriever)
+		delete(doc.MetaData, MetaKeyRank)
+		doc.MetaData[MetaKeyFusionRanks] = fd.ranks
+		f.setter(doc, fd.score)
+		ret = append(ret, doc)
+	}
+	return ret, nil
+}
+
+// values normalizes the scores of the documents of one retriever.
+func (f *fusion) values(docs []*schema.Document) []float64 {
+	if f.method == FusionRRF || len(docs) == 0 {
+		return nil
+	}
+	scores := make([]float64, len(docs))
+	for i, doc := range docs {
+		scores[i] = f.getter(doc)
+	}
+
+	switch f.method {
+	case FusionMinMax:
+		lo, hi := scores[0], scores[0]
+		for _, s := range scores {
+			lo, hi = math.Min(lo, s), math.Max(hi, s)
+		}
+		for i, s := range scores {
+			if hi == lo {
+				// a single result, or results all scored alike, are all as relevant as can be
+				scores[i] = 1
+			} else {
+				scores[i] = (s - lo) / (hi - lo)
+			}
+		}
+	case FusionZScore:
+		var mean, variance float64
+		for _, s := range scores {
+			mean += s
+		}
+		mean /= float64(len(scores))
+		for _, s := range scores {
+			variance += (s - mean) * (s - mean)
+		}
+		std := math.Sqrt(variance / float64(len(scores)))
+		for i, s := range scores {
+			if std == 0 {
+				scores[i] = 0
+			} else {
+				scores[i] = (s - mean) / std
+			}
+		}
+	}
+	return scores
+}
+
+func (f *fusion) dedupeKey(doc *schema.Document) string {
+	if f.dedupeBy == DedupeByID && doc.ID != "" {
+		return "id:" + doc.ID
+	}
+	sum := sha256.Sum256([]byte(doc.Content))
+	return "content:" + hex.EncodeToString(sum[:])
+}
+
+func (f *fusion) GetType() string {
+	return "ScoreFusion"
+}
+
+func retrieverOf(doc *schema.Document) string {
+	if doc.MetaData == nil {
+		return ""
+	}
+	name, _ := doc.MetaData[MetaKeyRetriever].(string)
+	return name
+}
+
+// rankOf returns the rank of the document from its metadata, or its 1-based position among the results of its retriever.
+func rankOf(doc *schema.Document, index int) int {
+	if doc.MetaData != nil {
+		switch v := doc.MetaData[MetaKeyRank].(type) {
+		case int:
+			return v
+		case int64:
+			return int(v)
+		case float64:
+			return int(v)
+		}
+	}
+	return index + 1
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package score
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func fusionInput() []*schema.Document {
+	keyword := TagDocuments("es8", []*schema.Document{
+		{ID: "a", Content: "A", MetaData: map[string]any{"_score": 12.0}},
+		{ID: "b", Content: "B", MetaData: map[string]any{"_score": 8.0}},
+		{ID: "c", Content: "C", MetaData: map[string]any{"_score": 2.0}},
+	})
+	vector := TagDocuments("milvus", []*schema.Document{
+		{ID: "c", Content: "C", MetaData: map[string]any{"_score": 0.9}},
+		{ID: "d", Content: "D", MetaData: map[string]any{"_score": 0.8}},
+		{ID: "a", Content: "A", MetaData: map[string]any{"_score": 0.5}},
+	})
+	return append(keyword, vector...)
+}
+
+func fusedIDs(docs []*schema.Document) []string {
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestFusion(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		config *FusionConfig
+		ids    []string
+		scores []float64
+	}{
+		{
+			name:   "rrf",
+			config: &FusionConfig{RRFK: 1},
+			ids:    []string{"a", "c", "b", "d"},
+			scores: []float64{1.0/2 + 1.0/4, 1.0/4 + 1.0/2, 1.0 / 3, 1.0 / 3},
+		},
+		{
+			name:   "weighted rrf",
+			config: &FusionConfig{RRFK: 1, Weights: map[string]float64{"milvus": 2}},
+			ids:    []string{"c", "a", "d", "b"},
+			scores: []float64{1.0/4 + 2.0/2, 1.0/2 + 2.0/4, 2.0 / 3, 1.0 / 3},
+		},
+		{
+			name:   "min max",
+			config: &FusionConfig{Method: FusionMinMax, TopK: 3},
+			ids:    []string{"a", "c", "d"},
+			scores: []float64{1, 1, 0.75},
+		},
+		{
+			name:   "z score",
+			config: &FusionConfig{Method: FusionZScore, Weights: map[string]float64{"es8": 0.5}},
+			ids:    []string{"d", "c", "b", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := fusionInput()
+			f, err := NewFusion(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := f.Transform(ctx, input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ids := fusedIDs(result); !reflect.DeepEqual(ids, tt.ids) {
+				t.Fatalf("got %v, want %v", ids, tt.ids)
+			}
+			for i, want := range tt.scores {
+				if got := result[i].Score(); math.Abs(got-want) > 1e-9 {
+					t.Fatalf("score of %s: got %v, want %v", result[i].ID, got, want)
+				}
+			}
+			// input documents are left untouched
+			if input[0].Score() != 12.0 {
+				t.Fatalf("input modified: %v", input[0].MetaData)
+			}
+		})
+	}
+}
+
+func TestFusion_Dedupe(t *testing.T) {
+	ctx := context.Background()
+	input := []*schema.Document{
+		{ID: "es-1", Content: "same chunk", MetaData: map[string]any{MetaKeyRetriever: "es8", MetaKeyRank: 2}},
+		{ID: "es-2", Content: "other chunk", MetaData: map[string]any{MetaKeyRetriever: "es8", MetaKeyRank: 1}},
+		{ID: "mv-1", Content: "same chunk", MetaData: map[string]any{MetaKeyRetriever: "milvus"}},
+		{Content: "no id"},
+		{Content: "no id"},
+	}
+
+	f, err := NewFusion(ctx, &FusionConfig{DedupeBy: DedupeByContent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := f.Transform(ctx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := fusedIDs(result); !reflect.DeepEqual(ids, []string{"es-1", "es-2", ""}) {
+		t.Fatalf("got %v", ids)
+	}
+	if ranks := result[0].MetaData[MetaKeyFusionRanks]; !reflect.DeepEqual(ranks, map[string]int{"es8": 2, "milvus": 1}) {
+		t.Fatalf("got ranks %v", ranks)
+	}
+	if _, ok := result[0].MetaData[MetaKeyRetriever]; ok {
+		t.Fatalf("retriever of the first duplicate kept: %v", result[0].MetaData)
+	}
+	if _, ok := result[0].MetaData[MetaKeyRank]; ok {
+		t.Fatalf("rank of the first duplicate kept: %v", result[0].MetaData)
+	}
+	if input[0].MetaData[MetaKeyRetriever] != "es8" {
+		t.Fatalf("input modified: %v", input[0].MetaData)
+	}
+	if ranks := result[2].MetaData[MetaKeyFusionRanks]; !reflect.DeepEqual(ranks, map[string]int{"": 1}) {
+		t.Fatalf("got ranks %v", ranks)
+	}
+
+	// by id, documents without id fall back to their content
+	f, err = NewFusion(ctx, &FusionConfig{ScoreFieldKey: &scoreKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = f.Transform(ctx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := fusedIDs(result); !reflect.DeepEqual(ids, []string{"es-2", "mv-1", "", "es-1"}) {
+		t.Fatalf("got %v", ids)
+	}
+	if _, ok := result[0].MetaData[scoreKey].(float64); !ok {
+		t.Fatalf("fused score not written to %s: %v", scoreKey, result[0].MetaData)
+	}
+
+	if _, err = NewFusion(ctx, &FusionConfig{Method: "borda"}); err == nil {
+		t.Fatal("expected error for unknown method")
+	}
+}
@@ -43,29 +43,30 @@ type Config struct {
 // - Document's Score() method (default)
 // - A custom metadata field specified by ScoreFieldKey in the config
 func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
-	var getter func(doc *schema.Document) float64
-	if config.ScoreFieldKey == nil {
-		getter = func(doc *schema.Document) float64 {
+	return &reranker{scoreGetter: newScoreGetter(config.ScoreFieldKey)}, nil
+}
+
+func newScoreGetter(scoreFieldKey *string) func(doc *schema.Document) float64 {
+	if scoreFieldKey == nil {
+		return func(doc *schema.Document) float64 {
 			return doc.Score()
 		}
-	} else {
-		key := *config.ScoreFieldKey
-		getter = func(doc *schema.Document) float64 {
-			if doc.MetaData == nil {
-				return 0
-			}
-			v, ok := doc.MetaData[key]
-			if !ok {
-				return 0
-			}
-			vv, okk := v.(float64)
-			if !okk {
-				return 0
-			}
-			return vv
+	}
+	key := *scoreFieldKey
+	return func(doc *schema.Document) float64 {
+		if doc.MetaData == nil {
+			return 0
+		}
+		v, ok := doc.MetaData[key]
+		if !ok {
+			return 0
+		}
+		vv, okk := v.(float64)
+		if !okk {
+			return 0
 		}
+		return vv
 	}
-	return &reranker{scoreGetter: getter}, nil
 }
 
 type reranker struct {
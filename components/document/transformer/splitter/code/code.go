@@ -0,0 +1,376 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyLanguage is the language of the chunk, see Language.
+	MetaKeyLanguage = "_language"
+	// MetaKeySymbol is the main symbol of the chunk, the first definition in it or the definition enclosing it.
+	// Methods are named "Type.Method" for go.
+	MetaKeySymbol = "_symbol"
+	// MetaKeySymbols are all definitions starting in the chunk, []string.
+	MetaKeySymbols = "_symbols"
+	// MetaKeyStartLine is the 1-based first line of the chunk in the source.
+	MetaKeyStartLine = "_start_line"
+	// MetaKeyEndLine is the 1-based last line of the chunk in the source, inclusive.
+	MetaKeyEndLine = "_end_line"
+)
+
+// metadata keys set by the file, url and s3 loaders, used to detect the language of a document.
+var pathMetaKeys = []string{"_extension", "_file_name", "_source", "_object_key"}
+
+// IDGenerator generates new IDs for split chunks,
+// originalID is the ID of the parent document, or a digest of its content if the parent has no ID.
+type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
+
+// defaultIDGenerator names chunks "<original ID>_<split index>"
+func defaultIDGenerator(ctx context.Context, originalID string, splitIndex int) string {
+	return originalID + "_" + strconv.Itoa(splitIndex)
+}
+
+type Config struct {
+	// ChunkSize is the maximum length of a chunk, required.
+	// Definitions such as functions, methods and types are kept whole when they fit, and only split further when they do not.
+	ChunkSize int
+	// Language of the documents. If empty, it is detected per document from the file extension found in
+	// the "_extension", "_file_name", "_source" or "_object_key" metadata.
+	// Documents of unknown language are split by blank lines, then by lines.
+	Language Language
+	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	LenFunc func(string) int
+	// IDGenerator is an optional function to generate new IDs for split chunks.
+	// If nil, chunks are named "<original ID>_<split index>".
+	IDGenerator IDGenerator
+}
+
+// NewSplitter creates a splitter for source code, which splits at definition boundaries of the language,
+// go sources are parsed with go/parser, other languages are matched line by line.
+func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if config.ChunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be greater than zero")
+	}
+	if config.Language != "" {
+		if _, ok := languageSpecs[config.Language]; !ok {
+			return nil, fmt.Errorf("unsupported language: %s", config.Language)
+		}
+	}
+
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
+	idGenerator := config.IDGenerator
+	if idGenerator == nil {
+		idGenerator = defaultIDGenerator
+	}
+	return &splitter{
+		chunkSize:   config.ChunkSize,
+		language:    config.Language,
+		lenFunc:     lenFunc,
+		idGenerator: idGenerator,
+	}, nil
+}
+
+type splitter struct {
+	chunkSize   int
+	language    Language
+	lenFunc     func(string) int
+	idGenerator IDGenerator
+}
+
+type span struct {
+	start int
+	end   int
+}
+
+func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		lang := s.language
+		if lang == "" {
+			lang = detectFromMeta(doc.MetaData)
+		}
+
+		text := doc.Content
+		lines := lineStarts(text)
+		defs := s.definitions(lang, text, lines)
+
+		levels, defLevels := cutLevels(text, lines, defs)
+		var chunks []span
+		for _, sp := range s.splitSpan(text, span{0, len(text)}, levels, defLevels, 0) {
+			if sp, ok := trimSpan(text, sp); ok {
+				chunks = append(chunks, sp)
+			}
+		}
+
+		parent := parentID(doc)
+		positions := newChunkPositions(doc, parent, len(chunks))
+		for i, sp := range chunks {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]any, 10)
+			}
+			positions.set(meta, i, sp.start, sp.end)
+			if lang != "" {
+				meta[MetaKeyLanguage] = string(lang)
+			}
+			meta[MetaKeyStartLine] = lineNumber(lines, sp.start)
+			meta[MetaKeyEndLine] = lineNumber(lines, sp.end-1)
+			if symbols := chunkSymbols(defs, sp); len(symbols) > 0 {
+				meta[MetaKeySymbol] = symbols[0]
+				meta[MetaKeySymbols] = symbols
+			} else if def := enclosingDefinition(defs, sp); def != nil {
+				meta[MetaKeySymbol] = def.name
+			}
+
+			ret = append(ret, &schema.Document{
+				ID:       s.idGenerator(ctx, parent, i),
+				Content:  text[sp.start:sp.end],
+				MetaData: meta,
+			})
+		}
+	}
+	return ret, nil
+}
+
+func (s *splitter) definitions(lang Language, text string, lines []int) []*definition {
+	if lang == LanguageGo {
+		if defs, ok := goDefinitions(text); ok {
+			return defs
+		}
+	}
+	if spec, ok := languageSpecs[lang]; ok {
+		return findDefinitions(spec, text, lines)
+	}
+	return nil
+}
+
+// cutLevels returns the offsets where the text may be split, from the most to the least preferred:
+// definitions by increasing indentation, paragraphs after blank lines, then every line.
+func cutLevels(text string, lines []int, defs []*definition) (levels [][]int, defLevels int) {
+	byIndent := map[int][]int{}
+	for _, def := range defs {
+		byIndent[def.indent] = append(byIndent[def.indent], def.start)
+	}
+	indents := make([]int, 0, len(byIndent))
+	for indent := range byIndent {
+		indents = append(indents, indent)
+	}
+	sort.Ints(indents)
+
+	levels = make([][]int, 0, len(indents)+2)
+	for _, indent := range indents {
+		levels = append(levels, byIndent[indent])
+	}
+
+	var paragraphs []int
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lineAt(text, lines, i-1)) == "" && strings.TrimSpace(lineAt(text, lines, i)) != "" {
+			paragraphs = append(paragraphs, lines[i])
+		}
+	}
+	return append(levels, paragraphs, lines), len(indents)
+}
+
+// splitSpan splits sp at the cuts of the given level, merging adjacent pieces as long as they fit in a chunk,
+// pieces still too large are split with the next levels. The first defLevels levels are definitions.
+func (s *splitter) splitSpan(text string, sp span, levels [][]int, defLevels, level int) []span {
+	if s.fits(text, sp) {
+		return []span{sp}
+	}
+
+	var cuts []int
+	for ; level < len(levels); level++ {
+		cuts = cutsWithin(levels[level], sp)
+		if len(cuts) > 0 {
+			break
+		}
+	}
+	if len(cuts) == 0 {
+		return s.hardSplit(text, sp)
+	}
+
+	pieces := make([]span, 0, len(cuts)+1)
+	start := sp.start
+	for _, cut := range append(cuts, sp.end) {
+		pieces = append(pieces, span{start, cut})
+		start = cut
+	}
+
+	var ret []span
+	flush := func(cur span) {
+		if s.fits(text, cur) {
+			ret = append(ret, cur)
+		} else {
+			ret = append(ret, s.splitSpan(text, cur, levels, defLevels, level+1)...)
+		}
+	}
+	cur := pieces[0]
+	for _, piece := range pieces[1:] {
+		if merged := (span{cur.start, piece.end}); s.fits(text, merged) {
+			cur = merged
+			continue
+		}
+		flush(cur)
+		cur = piece
+	}
+	flush(cur)
+
+	if level < defLevels {
+		return ret
+	}
+	// below definitions, the fragments left by splitting a large piece may join their neighbours.
+	merged := ret[:1]
+	for _, sp := range ret[1:] {
+		last := &merged[len(merged)-1]
+		if s.fits(text, span{last.start, sp.end}) {
+			last.end = sp.end
+		} else {
+			merged = append(merged, sp)
+		}
+	}
+	return merged
+}
+
+// cutsWithin returns the sorted cuts strictly inside sp.
+func cutsWithin(cuts []int, sp span) []int {
+	i := sort.SearchInts(cuts, sp.start+1)
+	j := sort.SearchInts(cuts, sp.end)
+	if i >= j {
+		return nil
+	}
+	ret := make([]int, 0, j-i)
+	for k := i; k < j; k++ {
+		if len(ret) == 0 || ret[len(ret)-1] != cuts[k] {
+			ret = append(ret, cuts[k])
+		}
+	}
+	return ret
+}
+
+// hardSplit splits a single overlong line, preferably after whitespace, at least one rune per chunk.
+func (s *splitter) hardSplit(text string, sp span) []span {
+	var ret []span
+	start, lastSpace := sp.start, -1
+	for i := sp.start; i < sp.end; {
+		r, size := utf8.DecodeRuneInString(text[i:sp.end])
+		if i > start && !s.fits(text, span{start, i + size}) {
+			cut := i
+			if lastSpace > start {
+				cut = lastSpace
+			}
+			ret = append(ret, span{start, cut})
+			start, lastSpace = cut, -1
+			continue
+		}
+		i += size
+		if unicode.IsSpace(r) {
+			lastSpace = i
+		}
+	}
+	if start < sp.end {
+		ret = append(ret, span{start, sp.end})
+	}
+	return ret
+}
+
+func (s *splitter) fits(text string, sp span) bool {
+	return s.lenFunc(text[sp.start:sp.end]) <= s.chunkSize
+}
+
+// trimSpan drops leading blank lines and trailing whitespace, keeping the indentation of the first line.
+func trimSpan(text string, sp span) (span, bool) {
+	content := text[sp.start:sp.end]
+	first := strings.IndexFunc(content, func(r rune) bool { return !unicode.IsSpace(r) })
+	if first < 0 {
+		return sp, false
+	}
+	sp.start += strings.LastIndexByte(content[:first], '\n') + 1
+	sp.end = sp.start + len(strings.TrimRightFunc(text[sp.start:sp.end], unicode.IsSpace))
+	return sp, true
+}
+
+// lineNumber returns the 1-based line of offset.
+func lineNumber(lines []int, offset int) int {
+	return sort.Search(len(lines), func(i int) bool { return lines[i] > offset })
+}
+
+func chunkSymbols(defs []*definition, sp span) []string {
+	var symbols []string
+	for _, def := range defs {
+		if def.name != "" && def.line >= sp.start && def.line < sp.end {
+			symbols = append(symbols, def.name)
+		}
+	}
+	return symbols
+}
+
+// enclosingDefinition returns the innermost named definition containing the start of sp.
+func enclosingDefinition(defs []*definition, sp span) *definition {
+	var ret *definition
+	for _, def := range defs {
+		if def.name != "" && def.line < sp.start && sp.start < def.end && (ret == nil || def.line > ret.line) {
+			ret = def
+		}
+	}
+	return ret
+}
+
+func detectFromMeta(meta map[string]any) Language {
+	for _, key := range pathMetaKeys {
+		if v, ok := meta[key].(string); ok && v != "" {
+			if key == "_extension" && !strings.HasPrefix(v, ".") {
+				v = "." + v
+			}
+			if lang := DetectLanguage(v); lang != "" {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+func (s *splitter) GetType() string {
+	return "CodeSplitter"
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
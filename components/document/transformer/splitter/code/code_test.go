@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type chunk struct {
+	lines   [2]int
+	symbol  any
+	symbols any
+}
+
+func split(t *testing.T, config *Config, doc *schema.Document) []chunk {
+	s, err := NewSplitter(context.Background(), config)
+	assert.NoError(t, err)
+	docs, err := s.Transform(context.Background(), []*schema.Document{doc})
+	assert.NoError(t, err)
+
+	lines := strings.Split(doc.Content, "\n")
+	var ret []chunk
+	for i, d := range docs {
+		assert.Equal(t, parentID(doc)+"_"+strconv.Itoa(i), d.ID)
+		assert.Equal(t, i, d.MetaData[MetaKeyChunkIndex])
+		assert.Equal(t, len(docs), d.MetaData[MetaKeyTotalChunks])
+		assert.Equal(t, d.Content, string([]rune(doc.Content)[d.MetaData[MetaKeyStartOffset].(int):d.MetaData[MetaKeyEndOffset].(int)]))
+
+		start, end := d.MetaData[MetaKeyStartLine].(int), d.MetaData[MetaKeyEndLine].(int)
+		assert.Equal(t, strings.TrimRight(strings.Join(lines[start-1:end], "\n"), " \n"), d.Content)
+		ret = append(ret, chunk{
+			lines:   [2]int{start, end},
+			symbol:  d.MetaData[MetaKeySymbol],
+			symbols: d.MetaData[MetaKeySymbols],
+		})
+	}
+	return ret
+}
+
+func readDoc(t *testing.T, name string) *schema.Document {
+	data, err := os.ReadFile("testdata/" + name)
+	assert.NoError(t, err)
+	return &schema.Document{ID: name, Content: string(data), MetaData: map[string]any{"_source": "/repo/" + name}}
+}
+
+func TestSplitter_Go(t *testing.T) {
+	doc := readDoc(t, "sample.go")
+
+	assert.Equal(t, []chunk{
+		{[2]int{1, 11}, "Greeter", []string{"Greeter"}},
+		{[2]int{13, 21}, "Greeter.Greet", []string{"Greeter.Greet", "A"}},
+		{[2]int{23, 30}, "Join", []string{"Join"}},
+		{[2]int{31, 36}, "Join", nil},
+	}, split(t, &Config{ChunkSize: 200}, doc))
+
+	assert.Equal(t, []chunk{
+		{[2]int{1, 6}, nil, nil},
+		{[2]int{8, 11}, "Greeter", []string{"Greeter"}},
+		{[2]int{13, 14}, "Greeter.Greet", []string{"Greeter.Greet"}},
+		{[2]int{15, 16}, "Greeter.Greet", nil},
+		{[2]int{18, 21}, "A", []string{"A"}},
+		{[2]int{23, 24}, "Join", []string{"Join"}},
+		{[2]int{25, 29}, "Join", nil},
+		{[2]int{30, 36}, "Join", nil},
+	}, split(t, &Config{ChunkSize: 100}, doc))
+
+	// broken sources fall back to line patterns
+	broken := &schema.Document{
+		Content:  "package a\n\nfunc A() {\n\treturn\n\nfunc (b *B) C() {\n}\n",
+		MetaData: map[string]any{"_extension": ".go"},
+	}
+	assert.Equal(t, []chunk{
+		{[2]int{1, 1}, nil, nil},
+		{[2]int{3, 4}, "A", []string{"A"}},
+		{[2]int{6, 7}, "C", []string{"C"}},
+	}, split(t, &Config{ChunkSize: 30}, broken))
+}
+
+func TestSplitter_Python(t *testing.T) {
+	doc := readDoc(t, "sample.py")
+
+	assert.Equal(t, []chunk{
+		{[2]int{1, 1}, nil, nil},
+		{[2]int{4, 8}, "Store", []string{"Store", "__init__"}},
+		{[2]int{10, 12}, "name", []string{"name"}},
+		{[2]int{14, 16}, "load", []string{"load"}},
+		{[2]int{19, 21}, "main", []string{"main"}},
+	}, split(t, &Config{ChunkSize: 120}, doc))
+
+	assert.Equal(t, []chunk{
+		{[2]int{1, 1}, nil, nil},
+		{[2]int{4, 12}, "Store", []string{"Store", "__init__", "name"}},
+		{[2]int{14, 16}, "load", []string{"load"}},
+		{[2]int{19, 21}, "main", []string{"main"}},
+	}, split(t, &Config{ChunkSize: 250}, doc))
+}
+
+func TestSplitter_TypeScript(t *testing.T) {
+	doc := readDoc(t, "sample.ts")
+
+	assert.Equal(t, []chunk{
+		{[2]int{1, 5}, "Options", []string{"Options"}},
+		{[2]int{7, 11}, "load", []string{"load"}},
+		{[2]int{12, 15}, "load", nil},
+		{[2]int{17, 19}, "parse", []string{"parse"}},
+		{[2]int{21, 22}, "Loader", []string{"Loader", "constructor"}},
+		{[2]int{24, 27}, "run", []string{"run"}},
+	}, split(t, &Config{ChunkSize: 120}, doc))
+}
+
+func TestSplitter_PlainText(t *testing.T) {
+	doc := &schema.Document{Content: "first paragraph\nstill first\n\nsecond " + strings.Repeat("x", 12) + " end"}
+
+	s, err := NewSplitter(context.Background(), &Config{ChunkSize: 16})
+	assert.NoError(t, err)
+	docs, err := s.Transform(context.Background(), []*schema.Document{doc})
+	assert.NoError(t, err)
+
+	var contents []string
+	for _, d := range docs {
+		contents = append(contents, d.Content)
+		assert.NotContains(t, d.MetaData, MetaKeyLanguage)
+		assert.NotContains(t, d.MetaData, MetaKeySymbol)
+	}
+	assert.Equal(t, []string{"first paragraph", "still first", "second", "xxxxxxxxxxxx end"}, contents)
+	assert.Equal(t, 4, docs[2].MetaData[MetaKeyStartLine])
+}
+
+func TestDefinitions(t *testing.T) {
+	cases := []struct {
+		lang Language
+		code string
+		want []string
+	}{
+		{LanguageJava, "@Service\npublic class UserService {\n    private final Repo repo;\n    public List<User> findAll(int limit) {\n        return repo.findAll(limit);\n    }\n}", []string{"UserService", "findAll"}},
+		{LanguageRust, "#[derive(Debug)]\npub struct Point {\n    x: i32,\n}\n\nimpl Display for Point {\n    pub fn fmt(&self) -> String {\n        format(self)\n    }\n}", []string{"Point", "Point", "fmt"}},
+		{LanguageCpp, "namespace ns {\nclass Foo : public Bar {\n};\nint Foo::size() const {\n    return call(1);\n}\n}", []string{"ns", "Foo", "Foo::size"}},
+		{LanguageC, "static int add(int a, int b) {\n    if (a > b) {\n        return a;\n    }\n}\ntypedef struct node {\n};", []string{"add", "node"}},
+		{LanguageCSharp, "namespace App.Core\n{\n    public sealed class Cache\n    {\n        public async Task<string> GetAsync(string key)\n        {\n            return await Load(key);\n        }\n    }\n}", []string{"App.Core", "Cache", "GetAsync"}},
+		{LanguageRuby, "module Shop\n  class Cart\n    def self.empty?\n    end\n  end\nend", []string{"Shop", "Cart", "empty?"}},
+		{LanguagePHP, "<?php\nfinal class Mailer {\n    public static function send($to) {\n    }\n}", []string{"Mailer", "send"}},
+		{LanguageKotlin, "data class User(val id: Int)\n\nsuspend fun List<User>.names(): List<String> {\n}", []string{"User", "names"}},
+		{LanguageJavaScript, "export default class App {\n  render() {\n    if (this.ready) {\n    }\n  }\n}\nconst handler = async (req) => {\n};", []string{"App", "render", "handler"}},
+	}
+	for _, c := range cases {
+		var names []string
+		for _, def := range findDefinitions(languageSpecs[c.lang], c.code, lineStarts(c.code)) {
+			names = append(names, def.name)
+		}
+		assert.Equal(t, c.want, names, c.lang)
+	}
+
+	// attached annotations and derives start the definition
+	code := "#[derive(Debug)]\n#[serde(rename_all = \"camelCase\")]\npub struct Point {\n}"
+	defs := findDefinitions(languageSpecs[LanguageRust], code, lineStarts(code))
+	assert.Equal(t, 0, defs[0].start)
+}
+
+func TestNewSplitter(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewSplitter(ctx, &Config{})
+	assert.Error(t, err)
+	_, err = NewSplitter(ctx, &Config{ChunkSize: 10, Language: "cobol"})
+	assert.Error(t, err)
+
+	assert.Equal(t, LanguageTypeScript, DetectLanguage("src/App.TSX"))
+	assert.Equal(t, LanguageGo, DetectLanguage(`C:\repo\main.go`))
+	assert.Equal(t, Language(""), DetectLanguage("README"))
+	assert.Equal(t, LanguageRust, detectFromMeta(map[string]any{"_extension": "rs"}))
+	assert.Equal(t, LanguagePython, detectFromMeta(map[string]any{"_object_key": "src/a.py"}))
+}
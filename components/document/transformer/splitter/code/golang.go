@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// goDefinitions finds the top level declarations of a go file with go/parser,
+// ok is false if the source can not be parsed.
+func goDefinitions(text string) (defs []*definition, ok bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", text, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, false
+	}
+
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+	for _, decl := range file.Decls {
+		def := &definition{
+			line: lineStartOf(text, offset(decl.Pos())),
+			end:  offset(decl.End()),
+		}
+		var doc *ast.CommentGroup
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			doc = d.Doc
+			def.name = d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				def.name = receiverName(d.Recv.List[0].Type) + "." + def.name
+			}
+		case *ast.GenDecl:
+			doc = d.Doc
+			def.name = genDeclName(d)
+		}
+		def.start = def.line
+		if doc != nil {
+			def.start = lineStartOf(text, offset(doc.Pos()))
+		}
+		defs = append(defs, def)
+	}
+	return defs, true
+}
+
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// genDeclName names a type, var or const declaration after its first spec, imports have no name.
+func genDeclName(d *ast.GenDecl) string {
+	if len(d.Specs) == 0 {
+		return ""
+	}
+	switch s := d.Specs[0].(type) {
+	case *ast.TypeSpec:
+		return s.Name.Name
+	case *ast.ValueSpec:
+		if len(s.Names) > 0 {
+			return s.Names[0].Name
+		}
+	}
+	return ""
+}
+
+func lineStartOf(text string, offset int) int {
+	return strings.LastIndexByte(text[:offset], '\n') + 1
+}
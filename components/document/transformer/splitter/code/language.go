@@ -0,0 +1,276 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Language is the programming language of a source file.
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJava       Language = "java"
+	LanguageKotlin     Language = "kotlin"
+	LanguageJavaScript Language = "javascript"
+	LanguageTypeScript Language = "typescript"
+	LanguageRust       Language = "rust"
+	LanguageC          Language = "c"
+	LanguageCpp        Language = "cpp"
+	LanguageCSharp     Language = "csharp"
+	LanguageRuby       Language = "ruby"
+	LanguagePHP        Language = "php"
+)
+
+var extLanguages = map[string]Language{
+	".go":   LanguageGo,
+	".py":   LanguagePython,
+	".pyi":  LanguagePython,
+	".java": LanguageJava,
+	".kt":   LanguageKotlin,
+	".kts":  LanguageKotlin,
+	".js":   LanguageJavaScript,
+	".jsx":  LanguageJavaScript,
+	".mjs":  LanguageJavaScript,
+	".cjs":  LanguageJavaScript,
+	".ts":   LanguageTypeScript,
+	".tsx":  LanguageTypeScript,
+	".mts":  LanguageTypeScript,
+	".rs":   LanguageRust,
+	".c":    LanguageC,
+	".h":    LanguageC,
+	".cc":   LanguageCpp,
+	".cpp":  LanguageCpp,
+	".cxx":  LanguageCpp,
+	".hpp":  LanguageCpp,
+	".hh":   LanguageCpp,
+	".cs":   LanguageCSharp,
+	".rb":   LanguageRuby,
+	".php":  LanguagePHP,
+}
+
+// DetectLanguage returns the language of a file by its extension, or "" if unknown.
+func DetectLanguage(filePath string) Language {
+	return extLanguages[strings.ToLower(path.Ext(strings.ReplaceAll(filePath, "\\", "/")))]
+}
+
+// languageSpec describes how definitions look like in a language.
+// Every definition pattern captures the indentation as group 1 and the symbol name as group 2.
+type languageSpec struct {
+	definitions []*regexp.Regexp
+	// attached are the line prefixes (comments, decorators, annotations) kept together with the definition below them.
+	attached []string
+}
+
+var cLikeAttached = []string{"//", "/*", "*", "@"}
+
+var languageSpecs = map[Language]*languageSpec{
+	// only used when the go source can not be parsed
+	LanguageGo: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^()(?:func|type)\s+(?:\([^)]*\)\s*)?(\w+)`),
+		},
+		attached: []string{"//"},
+	},
+	LanguagePython: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:async\s+)?(?:def|class)\s+(\w+)`),
+		},
+		attached: []string{"#", "@"},
+	},
+	LanguageJava: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:(?:public|protected|private|static|final|abstract|sealed|non-sealed|strictfp)\s+)*(?:class|interface|enum|record|@interface)\s+(\w+)`),
+			regexp.MustCompile(`^([ \t]*)(?:(?:public|protected|private|static|final|abstract|synchronized|native|default)\s+)*(?:<[^>]*>\s*)?[\w.]+(?:<[^()]*>)?(?:\[\])*\s+(\w+)\s*\([^;]*$`),
+		},
+		attached: cLikeAttached,
+	},
+	LanguageKotlin: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:(?:public|private|protected|internal|open|abstract|override|final|suspend|inline|data|sealed|enum|annotation|inner|value|companion|operator|infix|tailrec)\s+)*(?:fun|class|interface|object)\s+(?:<[^>]*>\s*)?(?:[\w.]+(?:<[^>]*>)?\??\.)?(\w+)`),
+		},
+		attached: cLikeAttached,
+	},
+	LanguageJavaScript: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:export\s+)?(?:default\s+)?(?:async\s+)?(?:function\s*\*?|class)\s*(\w+)`),
+			regexp.MustCompile(`^([ \t]*)(?:export\s+)?(?:const|let|var)\s+(\w+)\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)`),
+			regexp.MustCompile(`^([ \t]+)(?:(?:static|async|get|set)\s+)*\*?(\w+)\s*\([^)]*\)\s*\{`),
+		},
+		attached: cLikeAttached,
+	},
+	LanguageTypeScript: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:async\s+)?(?:function\s*\*?|class|interface|enum|type|namespace|module)\s*(\w+)`),
+			regexp.MustCompile(`^([ \t]*)(?:export\s+)?(?:const|let|var)\s+(\w+)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*(?::[^=]+)?=>|\w+\s*=>)`),
+			regexp.MustCompile(`^([ \t]+)(?:(?:public|private|protected|static|async|readonly|override|abstract|get|set)\s+)*\*?(\w+)\s*(?:<[^>]*>)?\([^)]*\)\s*(?::[^{]*)?\{`),
+		},
+		attached: cLikeAttached,
+	},
+	LanguageRust: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:pub(?:\([^)]*\))?\s+)?(?:(?:async|const|unsafe|default|extern(?:\s+"[^"]*")?)\s+)*(?:fn|struct|enum|trait|union|mod|type|macro_rules!)\s*(\w+)`),
+			regexp.MustCompile(`^([ \t]*)(?:unsafe\s+)?impl(?:<[^>]*>)?\s+(?:[\w:]+(?:<[^>]*>)?\s+for\s+)?(\w+)`),
+		},
+		attached: []string{"//", "/*", "*", "#["},
+	},
+	LanguageC: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:typedef\s+)?(?:struct|union|enum)\s+(\w+)\s*\{`),
+			regexp.MustCompile(`^()(?:[\w*]+[ \t*]+)+\**(\w+)\s*\([^;]*$`),
+		},
+		attached: []string{"//", "/*", "*"},
+	},
+	LanguageCpp: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:template\s*<[^>]*>\s*)?(?:class|struct|union|namespace|enum(?:\s+class)?)\s+(\w+)(?:\s*(?::[^{;]*)?\{|\s*$)`),
+			regexp.MustCompile(`^([ \t]*)(?:template\s*<[^>]*>\s*)?(?:[\w:*&<>,]+[ \t*&]+)*[*&]*((?:\w+::)*~?\w+)\s*\([^;]*$`),
+		},
+		attached: []string{"//", "/*", "*", "template"},
+	},
+	LanguageCSharp: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:(?:public|protected|private|internal|static|sealed|abstract|partial|readonly|unsafe|file)\s+)*(?:class|interface|struct|enum|record|namespace)\s+([\w.]+)`),
+			regexp.MustCompile(`^([ \t]*)(?:(?:public|protected|private|internal|static|virtual|override|abstract|sealed|async|extern|unsafe|new)\s+)*[\w.]+(?:<[^()]*>)?(?:\[\])*\??\s+(\w+)\s*(?:<[^>]*>)?\([^;]*$`),
+		},
+		attached: []string{"//", "/*", "*", "["},
+	},
+	LanguageRuby: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:def|class|module)\s+(?:self\.)?([\w:]+[?!=]?)`),
+		},
+		attached: []string{"#"},
+	},
+	LanguagePHP: {
+		definitions: []*regexp.Regexp{
+			regexp.MustCompile(`^([ \t]*)(?:(?:abstract|final|public|private|protected|static|readonly)\s+)*(?:function|class|interface|trait|enum)\s+&?(\w+)`),
+		},
+		attached: []string{"//", "/*", "*", "#["},
+	},
+}
+
+// statementKeywords can not start a definition, they filter out calls and control flow
+// matched by the loose method patterns, e.g. "return foo(" or "if (a) {".
+var statementKeywords = map[string]bool{
+	"return": true, "new": true, "throw": true, "else": true, "if": true, "for": true, "foreach": true,
+	"while": true, "switch": true, "case": true, "catch": true, "do": true, "try": true, "await": true,
+	"yield": true, "delete": true, "typeof": true, "goto": true, "using": true, "lock": true, "when": true,
+	"sizeof": true, "elif": true, "with": true,
+}
+
+type definition struct {
+	// start is where a chunk should begin to keep the definition whole, including attached comments.
+	start int
+	// line is the offset of the line holding the definition.
+	line int
+	// end is the offset after the definition.
+	end    int
+	indent int
+	name   string
+}
+
+// findDefinitions matches definition patterns line by line,
+// the end of a definition is estimated as the next definition that is not more indented.
+func findDefinitions(spec *languageSpec, text string, lines []int) []*definition {
+	var defs []*definition
+	for i, start := range lines {
+		line := lineAt(text, lines, i)
+		for _, re := range spec.definitions {
+			m := re.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			if first := strings.Fields(line); statementKeywords[strings.TrimRight(first[0], "({")] {
+				continue
+			}
+			defs = append(defs, &definition{
+				start:  attachedStart(spec, text, lines, i),
+				line:   start,
+				end:    len(text),
+				indent: indentWidth(m[1]),
+				name:   m[2],
+			})
+			break
+		}
+	}
+
+	for i, def := range defs {
+		for _, next := range defs[i+1:] {
+			if next.indent <= def.indent {
+				def.end = next.start
+				break
+			}
+		}
+	}
+	return defs
+}
+
+// attachedStart walks up from line i over the contiguous comment and decorator lines belonging to it.
+func attachedStart(spec *languageSpec, text string, lines []int, i int) int {
+	for ; i > 0; i-- {
+		prev := strings.TrimSpace(lineAt(text, lines, i-1))
+		if prev == "" || !hasAnyPrefix(prev, spec.attached) {
+			break
+		}
+	}
+	return lines[i]
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func indentWidth(indent string) int {
+	w := 0
+	for _, c := range indent {
+		if c == '\t' {
+			w += 4
+		} else {
+			w++
+		}
+	}
+	return w
+}
+
+// lineStarts returns the offset of the beginning of every line.
+func lineStarts(text string) []int {
+	lines := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' && i+1 < len(text) {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+// lineAt returns line i without the line break.
+func lineAt(text string, lines []int, i int) string {
+	end := len(text)
+	if i+1 < len(lines) {
+		end = lines[i+1]
+	}
+	return strings.TrimRight(text[lines[i]:end], "\r\n")
+}
@@ -0,0 +1,36 @@
+package sample
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Greeter greets people.
+type Greeter struct {
+	Name string
+}
+
+// Greet returns a greeting.
+func (g *Greeter) Greet(who string) string {
+	return fmt.Sprintf("%s says hello to %s", g.Name, who)
+}
+
+const (
+	A = 1
+	B = 2
+)
+
+// Join joins words, it is long enough to be split.
+func Join(words []string) string {
+	var sb strings.Builder
+	for i, w := range words {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(w)
+	}
+
+	result := sb.String()
+	result = strings.TrimSpace(result)
+	return result
+}
@@ -0,0 +1,21 @@
+import os
+
+
+class Store:
+    """A key value store."""
+
+    def __init__(self, path):
+        self.path = path
+
+    @property
+    def name(self):
+        return os.path.basename(self.path)
+
+    def load(self):
+        with open(self.path) as f:
+            return f.read()
+
+
+async def main():
+    store = Store("/tmp/data")
+    print(store.load())
@@ -0,0 +1,27 @@
+import { readFile } from "fs/promises";
+
+export interface Options {
+  path: string;
+}
+
+/**
+ * Loads a file.
+ */
+export async function load(opts: Options): Promise<string> {
+  if (opts.path === "") {
+    throw new Error("empty path");
+  }
+  return readFile(opts.path, "utf-8");
+}
+
+export const parse = (text: string) => {
+  return text.split("\n");
+};
+
+export class Loader {
+  constructor(private readonly opts: Options) {}
+
+  async run(): Promise<string[]> {
+    return parse(await load(this.opts));
+  }
+}
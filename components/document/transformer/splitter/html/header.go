@@ -28,12 +28,13 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
-// IDGenerator generates new IDs for split chunks
+// IDGenerator generates new IDs for split chunks,
+// originalID is the ID of the parent document, or a digest of its content if the parent has no ID.
 type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
 
-// defaultIDGenerator keeps the original ID
-func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
-	return originalID
+// defaultIDGenerator names chunks "<original ID>_<split index>"
+func defaultIDGenerator(ctx context.Context, originalID string, splitIndex int) string {
+	return originalID + "_" + strconv.Itoa(splitIndex)
 }
 
 // HeaderConfig configures how HTML headers are identified and mapped to metadata keys
@@ -43,7 +44,7 @@ type HeaderConfig struct {
 	// Example: {"h1": "Title", "h2": "Section"} will track h1 and h2 headers
 	Headers map[string]string
 	// IDGenerator is an optional function to generate new IDs for split chunks.
-	// If nil, the original document ID will be used for all splits.
+	// If nil, chunks are named "<original ID>_<split index>".
 	IDGenerator IDGenerator
 }
 
@@ -95,18 +96,21 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 		if err != nil {
 			return nil, err
 		}
+		parent := parentID(doc)
+		positions := newChunkPositions(doc, parent, len(result))
 		for i := range result {
 			nDoc := &schema.Document{
-				ID:       h.idGenerator(ctx, doc.ID, i),
+				ID:       h.idGenerator(ctx, parent, i),
 				Content:  result[i].chunk,
 				MetaData: deepCopyAnyMap(doc.MetaData),
 			}
 			if nDoc.MetaData == nil {
-				nDoc.MetaData = make(map[string]any, len(result[i].meta))
+				nDoc.MetaData = make(map[string]any, len(result[i].meta)+5)
 			}
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
+			positions.set(nDoc.MetaData, i, result[i].start, result[i].end)
 			ret = append(ret, nDoc)
 		}
 	}
@@ -120,6 +124,9 @@ func (h *headerSplitter) GetType() string {
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	// start and end are the byte offsets of the chunk text in the html source, start is -1 if not located.
+	start int
+	end   int
 }
 
 type metaRecord struct {
@@ -139,29 +146,36 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) ([]splitRes
 		return nil, err
 	}
 
-	err = h.dfs(tree, recordedMetaList, recordedMetaMap, currentText, &ret)
+	loc := &textLocator{source: text, start: -1, end: -1}
+	err = h.dfs(tree, recordedMetaList, recordedMetaMap, currentText, loc, &ret)
 	if err != nil {
 		return nil, err
 	}
 	if currentText.Len() > 0 {
+		start, end := loc.take()
 		ret = append(ret, splitResult{
 			chunk: currentText.String(),
 			meta:  map[string]string{},
+			start: start,
+			end:   end,
 		})
 	}
 	return ret, nil
 }
 
-func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *strings.Builder, ret *[]splitResult) error {
+func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *strings.Builder, loc *textLocator, ret *[]splitResult) error {
 	hasHeader := false
 	for ; node != nil; node = node.NextSibling {
 		if _, ok := h.headers[node.Data]; ok && node.Type == html.ElementNode {
 			hasHeader = true
 
 			if currentText.Len() > 0 {
+				start, end := loc.take()
 				*ret = append(*ret, splitResult{
 					chunk: currentText.String(),
 					meta:  deepCopyMap(recordedMetaMap),
+					start: start,
+					end:   end,
 				})
 				currentText.Reset()
 			}
@@ -183,6 +197,8 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 			if err != nil {
 				return err
 			}
+			// the header text is not part of any chunk, skip it in the source
+			loc.locate(data)
 			record := metaRecord{
 				name:  h.headers[node.Data],
 				level: newLevel,
@@ -197,17 +213,21 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 		}
 		if node.Type == html.TextNode && len(strings.TrimSpace(node.Data)) != 0 {
 			currentText.WriteString(node.Data)
+			loc.add(node.Data)
 		}
 
-		err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), currentText, ret)
+		err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), currentText, loc, ret)
 		if err != nil {
 			return err
 		}
 	}
 	if hasHeader && currentText.Len() > 0 {
+		start, end := loc.take()
 		*ret = append(*ret, splitResult{
 			chunk: currentText.String(),
 			meta:  deepCopyMap(recordedMetaMap),
+			start: start,
+			end:   end,
 		})
 		currentText.Reset()
 	}
@@ -247,6 +267,46 @@ func extractText(node *html.Node) (string, error) {
 	return sb.String(), nil
 }
 
+// textLocator finds the text of the parsed nodes in the html source, in document order.
+type textLocator struct {
+	source string
+	cursor int
+	// start and end are the source range of the current chunk, start is -1 before any located text.
+	start int
+	end   int
+}
+
+// locate moves past text in the source and returns its range,
+// ok is false if it can not be found, e.g. when entities are written in another form.
+func (l *textLocator) locate(text string) (start, end int, ok bool) {
+	for _, s := range []string{text, html.EscapeString(text)} {
+		if i := strings.Index(l.source[l.cursor:], s); i >= 0 {
+			start = l.cursor + i
+			end = start + len(s)
+			l.cursor = end
+			return start, end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// add extends the current chunk with text.
+func (l *textLocator) add(text string) {
+	if start, end, ok := l.locate(text); ok {
+		if l.start < 0 {
+			l.start = start
+		}
+		l.end = end
+	}
+}
+
+// take returns the range of the current chunk and starts a new one.
+func (l *textLocator) take() (start, end int) {
+	start, end = l.start, l.end
+	l.start, l.end = -1, -1
+	return start, end
+}
+
 func deepCopySlice(s []metaRecord) []metaRecord {
 	ret := make([]metaRecord, len(s))
 	copy(ret, s)
@@ -88,62 +88,62 @@ func TestHTMLHeaderSplitter(t *testing.T) {
 			want: []*schema.Document{{
 				ID:      "id_part0",
 				Content: "H1 content1",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
-				},
+				}, 0, 9, 71, 82),
 			}, {
 				ID:      "id_part1",
 				Content: "H2.1 content",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
 					"Header2": "H2.1",
-				},
+				}, 1, 9, 142, 154),
 			}, {
 				ID:      "id_part2",
 				Content: "H3.1 content",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
 					"Header2": "H2.1",
 					"Header3": "H3.1",
-				},
+				}, 2, 9, 200, 212),
 			}, {
 				ID:      "id_part3",
 				Content: "H3.2 content",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
 					"Header2": "H2.1",
 					"Header3": "H3.2",
-				},
+				}, 3, 9, 258, 270),
 			}, {
 				ID:      "id_part4",
 				Content: "H2.2 content",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
 					"Header2": "H2.2",
-				},
+				}, 4, 9, 316, 328),
 			}, {
 				ID:      "id_part5",
 				Content: "H2.3 content",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
 					"Header2": "H2.3",
-				},
+				}, 5, 9, 403, 415),
 			}, {
 				ID:      "id_part6",
 				Content: "H1 content2H1 content3",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "H1",
-				},
+				}, 6, 9, 449, 509),
 			}, {
 				ID:      "id_part7",
 				Content: "H2.4 content",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header2": "H2.4",
-				},
+				}, 7, 9, 553, 565),
 			}, {
 				ID:       "id_part8",
 				Content:  "content",
-				MetaData: map[string]interface{}{},
+				MetaData: withPosition(map[string]interface{}{}, 8, 9, 590, 597),
 			},
 			},
 		},
@@ -165,3 +165,12 @@ func TestHTMLHeaderSplitter(t *testing.T) {
 		})
 	}
 }
+
+func withPosition(meta map[string]interface{}, index, total, start, end int) map[string]interface{} {
+	meta[MetaKeyParentID] = "id"
+	meta[MetaKeyChunkIndex] = index
+	meta[MetaKeyTotalChunks] = total
+	meta[MetaKeyStartOffset] = start
+	meta[MetaKeyEndOffset] = end
+	return meta
+}
@@ -0,0 +1,329 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyJSONPath is the JSONPath of the chunk in the parent document, e.g. "$.paths['/users'].get".
+	// A chunk holding some of the elements of an array is located by a slice, e.g. "$.items[0:10]",
+	// and a chunk holding some of the members of an object by the path of the object.
+	MetaKeyJSONPath = "_json_path"
+	// MetaKeyYAMLDocument is the 0-based index of the document in a YAML stream the chunk belongs to.
+	MetaKeyYAMLDocument = "_yaml_document"
+)
+
+// Format is the format of the documents to split.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// IDGenerator generates new IDs for split chunks,
+// originalID is the ID of the parent document, or a digest of its content if the parent has no ID.
+type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
+
+// defaultIDGenerator names chunks "<original ID>_<split index>"
+func defaultIDGenerator(ctx context.Context, originalID string, splitIndex int) string {
+	return originalID + "_" + strconv.Itoa(splitIndex)
+}
+
+type Config struct {
+	// ChunkSize is the maximum length of a chunk, required.
+	// Objects and arrays larger than it are split into valid sub-documents along their members and elements,
+	// adjacent members and elements are grouped as long as they fit. A single scalar larger than ChunkSize is kept whole.
+	ChunkSize int
+	// Format of the documents. If empty, documents whose "_extension", "_file_name", "_source" or "_object_key" metadata
+	// ends with ".yaml" or ".yml" are YAML, others are JSON.
+	// JSON is written compactly in chunks, YAML is written with 2 spaces indentation.
+	Format Format
+	// SplitArrayElements makes every element of an array of objects a chunk of its own, even if the array fits in ChunkSize,
+	// e.g. one document per record of an exported table.
+	SplitArrayElements bool
+	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	LenFunc func(string) int
+	// IDGenerator is an optional function to generate new IDs for split chunks.
+	// If nil, chunks are named "<original ID>_<split index>".
+	IDGenerator IDGenerator
+}
+
+// NewSplitter creates a splitter for JSON and YAML documents.
+func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if config.ChunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be greater than zero")
+	}
+	switch config.Format {
+	case "", FormatJSON, FormatYAML:
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", config.Format)
+	}
+
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
+	idGenerator := config.IDGenerator
+	if idGenerator == nil {
+		idGenerator = defaultIDGenerator
+	}
+	return &splitter{
+		chunkSize:          config.ChunkSize,
+		format:             config.Format,
+		splitArrayElements: config.SplitArrayElements,
+		lenFunc:            lenFunc,
+		idGenerator:        idGenerator,
+	}, nil
+}
+
+type splitter struct {
+	chunkSize          int
+	format             Format
+	splitArrayElements bool
+	lenFunc            func(string) int
+	idGenerator        IDGenerator
+}
+
+type chunk struct {
+	content  string
+	path     string
+	document int
+}
+
+func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		format := s.format
+		if format == "" {
+			format = detectFormat(doc.MetaData)
+		}
+
+		var (
+			values []*value
+			err    error
+		)
+		if format == FormatYAML {
+			values, err = parseYAML(doc.Content)
+		} else {
+			var v *value
+			v, err = parseJSON(doc.Content)
+			values = []*value{v}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("split document[%s] failed: %w", doc.ID, err)
+		}
+
+		c := &chunker{splitter: s, format: format}
+		for i, v := range values {
+			c.document = i
+			if err = c.split(v, "$"); err != nil {
+				return nil, fmt.Errorf("split document[%s] failed: %w", doc.ID, err)
+			}
+		}
+
+		parent := parentID(doc)
+		positions := newChunkPositions(doc, parent, len(c.chunks))
+		for i, ck := range c.chunks {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]any, 5)
+			}
+			meta[MetaKeyJSONPath] = ck.path
+			if len(values) > 1 {
+				meta[MetaKeyYAMLDocument] = ck.document
+			}
+			// chunks are re-encoded, they have no offsets in the parent
+			positions.set(meta, i, -1, -1)
+
+			ret = append(ret, &schema.Document{
+				ID:       s.idGenerator(ctx, parent, i),
+				Content:  ck.content,
+				MetaData: meta,
+			})
+		}
+	}
+	return ret, nil
+}
+
+func (s *splitter) GetType() string {
+	return "JSONSplitter"
+}
+
+type chunker struct {
+	*splitter
+	format   Format
+	document int
+	chunks   []chunk
+}
+
+func (c *chunker) encode(v *value) (string, error) {
+	var buf bytes.Buffer
+	var err error
+	if c.format == FormatYAML {
+		err = encodeYAML(&buf, v)
+	} else {
+		err = encodeJSON(&buf, v)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+func (c *chunker) emit(content, path string) {
+	c.chunks = append(c.chunks, chunk{content: content, path: path, document: c.document})
+}
+
+// split emits v whole if it fits, otherwise groups its members or elements into chunks,
+// descending into those too large on their own.
+func (c *chunker) split(v *value, p string) error {
+	if c.splitArrayElements && v.kind == kindArray && len(v.items) > 0 && allObjects(v.items) {
+		for i, item := range v.items {
+			if err := c.split(item, indexPath(p, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	content, err := c.encode(v)
+	if err != nil {
+		return err
+	}
+	if v.kind == kindScalar || c.lenFunc(content) <= c.chunkSize {
+		c.emit(content, p)
+		return nil
+	}
+
+	// [start, end) are the members or elements of the current group, whose encoding is content
+	start, end := 0, 0
+	content = ""
+	flush := func() {
+		if end > start {
+			groupPath := p
+			if v.kind == kindArray && (start > 0 || end < len(v.items)) {
+				groupPath = fmt.Sprintf("%s[%d:%d]", p, start, end)
+			}
+			c.emit(content, groupPath)
+		}
+		start = end
+	}
+
+	for i := range v.items {
+		candidate, err := c.encode(v.slice(start, i+1))
+		if err != nil {
+			return err
+		}
+		if c.lenFunc(candidate) <= c.chunkSize {
+			content, end = candidate, i+1
+			continue
+		}
+		flush()
+
+		single, err := c.encode(v.slice(i, i+1))
+		if err != nil {
+			return err
+		}
+		if c.lenFunc(single) <= c.chunkSize {
+			content, start, end = single, i, i+1
+			continue
+		}
+
+		childPath := indexPath(p, i)
+		if v.isObject() {
+			childPath = keyPath(p, v.keys[i])
+		}
+		if err = c.split(v.items[i], childPath); err != nil {
+			return err
+		}
+		start, end = i+1, i+1
+	}
+	flush()
+	return nil
+}
+
+// slice returns an object with members [start, end) or an array with elements [start, end) of v.
+func (v *value) slice(start, end int) *value {
+	ret := &value{kind: v.kind, items: v.items[start:end]}
+	if v.isObject() {
+		ret.keys = v.keys[start:end]
+	}
+	return ret
+}
+
+func allObjects(items []*value) bool {
+	for _, item := range items {
+		if !item.isObject() {
+			return false
+		}
+	}
+	return true
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func keyPath(p, key string) string {
+	if identifier.MatchString(key) {
+		return p + "." + key
+	}
+	return p + "['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(key) + "']"
+}
+
+func indexPath(p string, i int) string {
+	return p + "[" + strconv.Itoa(i) + "]"
+}
+
+// metadata keys set by the file, url and s3 loaders, used to detect the format of a document.
+var pathMetaKeys = []string{"_extension", "_file_name", "_source", "_object_key"}
+
+func detectFormat(meta map[string]any) Format {
+	for _, key := range pathMetaKeys {
+		if v, ok := meta[key].(string); ok && v != "" {
+			switch strings.ToLower(strings.TrimPrefix(path.Ext("."+v), ".")) {
+			case "yaml", "yml":
+				return FormatYAML
+			}
+		}
+	}
+	return FormatJSON
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type chunkInfo struct {
+	path    string
+	content string
+}
+
+func transform(t *testing.T, config *Config, doc *schema.Document) []chunkInfo {
+	s, err := NewSplitter(context.Background(), config)
+	assert.NoError(t, err)
+	docs, err := s.Transform(context.Background(), []*schema.Document{doc})
+	assert.NoError(t, err)
+
+	var ret []chunkInfo
+	for i, d := range docs {
+		assert.Equal(t, doc.ID+"_"+string(rune('0'+i)), d.ID)
+		assert.Equal(t, i, d.MetaData[MetaKeyChunkIndex])
+		assert.Equal(t, len(docs), d.MetaData[MetaKeyTotalChunks])
+		assert.LessOrEqual(t, len(d.Content), config.ChunkSize)
+		ret = append(ret, chunkInfo{path: d.MetaData[MetaKeyJSONPath].(string), content: d.Content})
+	}
+	return ret
+}
+
+func readDoc(t *testing.T, name string) *schema.Document {
+	data, err := os.ReadFile("testdata/" + name)
+	assert.NoError(t, err)
+	return &schema.Document{ID: "doc", Content: string(data), MetaData: map[string]any{"_file_name": name}}
+}
+
+func TestSplitter_JSON(t *testing.T) {
+	doc := readDoc(t, "openapi.json")
+
+	chunks := transform(t, &Config{ChunkSize: 150}, doc)
+	assert.Equal(t, []chunkInfo{
+		{"$", `{"openapi":"3.0.0","info":{"title":"Pet Store","version":"1.0.0"}}`},
+		{"$.paths['/pets']", `{"get":{"summary":"List pets","parameters":[{"name":"limit","in":"query"}]}}`},
+		{"$.paths['/pets']", `{"post":{"summary":"Create a pet","description":"Creates a new pet in the store, the name must be unique."}}`},
+		{"$.paths", `{"/pets/{id}":{"get":{"summary":"Get a pet by id"}}}`},
+		{"$.tags[0:2]", `[{"name":"pets","description":"Everything about pets"},{"name":"store","description":"Access to the store <orders>"}]`},
+		{"$.tags[2:3]", `[{"name":"user","description":"Operations about users"}]`},
+	}, chunks)
+	for _, c := range chunks {
+		assert.True(t, json.Valid([]byte(c.content)), c.content)
+	}
+
+	chunks = transform(t, &Config{ChunkSize: 100, SplitArrayElements: true}, doc)
+	assert.Equal(t, []chunkInfo{
+		{"$", `{"openapi":"3.0.0","info":{"title":"Pet Store","version":"1.0.0"}}`},
+		{"$.paths['/pets']", `{"get":{"summary":"List pets","parameters":[{"name":"limit","in":"query"}]}}`},
+		{"$.paths['/pets'].post", `{"summary":"Create a pet","description":"Creates a new pet in the store, the name must be unique."}`},
+		{"$.paths", `{"/pets/{id}":{"get":{"summary":"Get a pet by id"}}}`},
+		{"$.tags[0]", `{"name":"pets","description":"Everything about pets"}`},
+		{"$.tags[1]", `{"name":"store","description":"Access to the store <orders>"}`},
+		{"$.tags[2]", `{"name":"user","description":"Operations about users"}`},
+	}, chunks)
+
+	// a document fitting in ChunkSize is kept whole, numbers are kept as written
+	chunks = transform(t, &Config{ChunkSize: 100}, &schema.Document{ID: "doc", Content: `{"a": 1.50, "b": [1e3, true, null]}`})
+	assert.Equal(t, []chunkInfo{{"$", `{"a":1.50,"b":[1e3,true,null]}`}}, chunks)
+
+	// scalars larger than ChunkSize are kept whole
+	s, err := NewSplitter(context.Background(), &Config{ChunkSize: 10})
+	assert.NoError(t, err)
+	docs, err := s.Transform(context.Background(), []*schema.Document{{Content: `{"it's": "0123456789", "b": 1}`}})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, `$['it\'s']`, docs[0].MetaData[MetaKeyJSONPath])
+	assert.Equal(t, `"0123456789"`, docs[0].Content)
+	assert.Equal(t, `{"b":1}`, docs[1].Content)
+}
+
+func TestSplitter_YAML(t *testing.T) {
+	doc := readDoc(t, "config.yaml")
+
+	s, err := NewSplitter(context.Background(), &Config{ChunkSize: 80, SplitArrayElements: true})
+	assert.NoError(t, err)
+	docs, err := s.Transform(context.Background(), []*schema.Document{doc})
+	assert.NoError(t, err)
+
+	var (
+		paths     []string
+		documents []any
+	)
+	for _, d := range docs {
+		paths = append(paths, d.MetaData[MetaKeyJSONPath].(string))
+		documents = append(documents, d.MetaData[MetaKeyYAMLDocument])
+		var v any
+		assert.NoError(t, yaml.Unmarshal([]byte(d.Content), &v), d.Content)
+	}
+	assert.Equal(t, []string{"$", "$.services[0]", "$.services[1]", "$.services[1]", "$"}, paths)
+	assert.Equal(t, []any{0, 0, 0, 0, 1}, documents)
+	// aliases are resolved so chunks are self-contained
+	assert.Equal(t, "name: api\nimage: example/api:1.2\nsettings:\n  timeout: 30s\n  retries: 3", docs[1].Content)
+	assert.Equal(t, "kind: ConfigMap\ndata:\n  LOG_LEVEL: debug", docs[4].Content)
+}
+
+func TestSplitter_Errors(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewSplitter(ctx, &Config{})
+	assert.Error(t, err)
+	_, err = NewSplitter(ctx, &Config{ChunkSize: 10, Format: "xml"})
+	assert.Error(t, err)
+
+	s, err := NewSplitter(ctx, &Config{ChunkSize: 10})
+	assert.NoError(t, err)
+	_, err = s.Transform(ctx, []*schema.Document{{Content: `{"a": 1`}})
+	assert.Error(t, err)
+	_, err = s.Transform(ctx, []*schema.Document{{Content: `{"a": 1} {}`}})
+	assert.Error(t, err)
+	_, err = s.Transform(ctx, []*schema.Document{{Content: "a: [1", MetaData: map[string]any{"_extension": ".yml"}}})
+	assert.Error(t, err)
+
+	// each level references the previous one 9 times, expanding to 9^9 values
+	bomb := "a: &a [x, x, x, x, x, x, x, x, x]\n"
+	for i, prev := 'b', 'a'; i <= 'i'; i, prev = i+1, i {
+		refs := strings.TrimSuffix(strings.Repeat("*"+string(prev)+", ", 9), ", ")
+		bomb += fmt.Sprintf("%c: &%c [%s]\n", i, i, refs)
+	}
+	_, err = s.Transform(ctx, []*schema.Document{{Content: bomb, MetaData: map[string]any{"_extension": ".yaml"}}})
+	assert.ErrorContains(t, err, "too many values")
+
+	assert.Equal(t, FormatYAML, detectFormat(map[string]any{"_source": "https://example.com/spec.YAML"}))
+	assert.Equal(t, FormatJSON, detectFormat(map[string]any{"_source": "https://example.com/spec"}))
+}
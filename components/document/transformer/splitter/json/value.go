@@ -0,0 +1,288 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type valueKind uint8
+
+const (
+	kindScalar valueKind = iota
+	kindObject
+	kindArray
+)
+
+// value is a parsed JSON or YAML value keeping the order of object members.
+type value struct {
+	kind valueKind
+	// keys are the member names of an object.
+	keys []string
+	// items are the member values of an object or the elements of an array.
+	items []*value
+	// raw is the encoded JSON of a scalar.
+	raw []byte
+	// node is the YAML node of a scalar.
+	node *yaml.Node
+}
+
+func (v *value) isObject() bool {
+	return v.kind == kindObject
+}
+
+// parseJSON parses one JSON value, numbers and strings are kept as they are written.
+func parseJSON(content string) (*value, error) {
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+	v, err := decodeJSON(dec)
+	if err != nil {
+		return nil, fmt.Errorf("parse json failed: %w", err)
+	}
+	if _, err = dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("parse json failed: unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+func decodeJSON(dec *json.Decoder) (*value, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			v := &value{kind: kindObject}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				item, err := decodeJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				v.keys = append(v.keys, keyTok.(string))
+				v.items = append(v.items, item)
+			}
+			if _, err = dec.Token(); err != nil {
+				return nil, err
+			}
+			return v, nil
+		case '[':
+			v := &value{kind: kindArray}
+			for dec.More() {
+				item, err := decodeJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				v.items = append(v.items, item)
+			}
+			if _, err = dec.Token(); err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	case json.Number:
+		return &value{raw: []byte(t)}, nil
+	case string:
+		raw, err := marshalString(t)
+		if err != nil {
+			return nil, err
+		}
+		return &value{raw: raw}, nil
+	case bool:
+		if t {
+			return &value{raw: []byte("true")}, nil
+		}
+		return &value{raw: []byte("false")}, nil
+	case nil:
+		return &value{raw: []byte("null")}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
+
+func marshalString(s string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// encodeJSON writes v as compact JSON.
+func encodeJSON(buf *bytes.Buffer, v *value) error {
+	switch v.kind {
+	case kindObject:
+		buf.WriteByte('{')
+		for i, key := range v.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			raw, err := marshalString(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(raw)
+			buf.WriteByte(':')
+			if err = encodeJSON(buf, v.items[i]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case kindArray:
+		buf.WriteByte('[')
+		for i, item := range v.items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		buf.Write(v.raw)
+	}
+	return nil
+}
+
+// parseYAML parses all documents of a YAML stream, aliases are replaced by copies of their anchors
+// so that every chunk is self-contained.
+func parseYAML(content string) ([]*value, error) {
+	dec := yaml.NewDecoder(strings.NewReader(content))
+	var docs []*value
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse yaml failed: %w", err)
+		}
+		c := &yamlConverter{budget: maxYAMLNodes}
+		v, err := c.convert(&node, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parse yaml failed: %w", err)
+		}
+		docs = append(docs, v)
+	}
+	return docs, nil
+}
+
+const (
+	maxYAMLDepth = 1000
+	// maxYAMLNodes bounds the values produced from one document, aliases included,
+	// so a small document with nested aliases can't expand exponentially.
+	maxYAMLNodes = 1 << 20
+)
+
+// yamlConverter converts a YAML node tree, expanding aliases within its budget of values.
+type yamlConverter struct {
+	budget int
+}
+
+func (c *yamlConverter) convert(node *yaml.Node, depth int) (*value, error) {
+	if depth > maxYAMLDepth {
+		return nil, errors.New("yaml is nested too deeply")
+	}
+	if node.Kind != yaml.DocumentNode && node.Kind != yaml.AliasNode {
+		if c.budget--; c.budget < 0 {
+			return nil, errors.New("yaml expands to too many values, check its aliases")
+		}
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return &value{node: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}}, nil
+		}
+		return c.convert(node.Content[0], depth+1)
+	case yaml.AliasNode:
+		return c.convert(node.Alias, depth+1)
+	case yaml.MappingNode:
+		v := &value{kind: kindObject}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			item, err := c.convert(node.Content[i+1], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			v.keys = append(v.keys, node.Content[i].Value)
+			v.items = append(v.items, item)
+		}
+		return v, nil
+	case yaml.SequenceNode:
+		v := &value{kind: kindArray}
+		for _, child := range node.Content {
+			item, err := c.convert(child, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			v.items = append(v.items, item)
+		}
+		return v, nil
+	default:
+		scalar := *node
+		scalar.Anchor = ""
+		return &value{node: &scalar}, nil
+	}
+}
+
+// encodeYAML writes v as a YAML document with 2 spaces indentation.
+func encodeYAML(buf *bytes.Buffer, v *value) error {
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(toYAMLNode(v)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func toYAMLNode(v *value) *yaml.Node {
+	switch v.kind {
+	case kindObject:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for i, key := range v.keys {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				toYAMLNode(v.items[i]))
+		}
+		return node
+	case kindArray:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v.items {
+			node.Content = append(node.Content, toYAMLNode(item))
+		}
+		return node
+	default:
+		return v.node
+	}
+}
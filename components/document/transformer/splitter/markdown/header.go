@@ -19,18 +19,21 @@ package markdown
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
 )
 
-// IDGenerator generates new IDs for split chunks
+// IDGenerator generates new IDs for split chunks,
+// originalID is the ID of the parent document, or a digest of its content if the parent has no ID.
 type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
 
-// defaultIDGenerator keeps the original ID
-func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
-	return originalID
+// defaultIDGenerator names chunks "<original ID>_<split index>"
+func defaultIDGenerator(ctx context.Context, originalID string, splitIndex int) string {
+	return originalID + "_" + strconv.Itoa(splitIndex)
 }
 
 type HeaderConfig struct {
@@ -60,7 +63,7 @@ type HeaderConfig struct {
 	// TrimHeaders specify if results contain header lines.
 	TrimHeaders bool
 	// IDGenerator is an optional function to generate new IDs for split chunks.
-	// If nil, the original document ID will be used for all splits.
+	// If nil, chunks are named "<original ID>_<split index>".
 	IDGenerator IDGenerator
 }
 
@@ -95,24 +98,30 @@ type headerSplitter struct {
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	// start and end are the byte offsets of the chunk in the text, start is -1 for an empty chunk.
+	start int
+	end   int
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	var ret []*schema.Document
 	for _, doc := range docs {
 		result := h.splitText(ctx, doc.Content)
+		parent := parentID(doc)
+		positions := newChunkPositions(doc, parent, len(result))
 		for i := range result {
 			nDoc := &schema.Document{
-				ID:       h.idGenerator(ctx, doc.ID, i),
+				ID:       h.idGenerator(ctx, parent, i),
 				Content:  result[i].chunk,
 				MetaData: deepCopyAnyMap(doc.MetaData),
 			}
 			if nDoc.MetaData == nil {
-				nDoc.MetaData = make(map[string]any, len(result[i].meta))
+				nDoc.MetaData = make(map[string]any, len(result[i].meta)+5)
 			}
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
+			positions.set(nDoc.MetaData, i, result[i].start, result[i].end)
 			ret = append(ret, nDoc)
 		}
 	}
@@ -141,11 +150,39 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 	var bInCodeBlock bool
 	var openingFence string
 	var ret []splitResult
+	// the source range of the current lines, and of the line being processed
+	chunkStart, chunkEnd := -1, -1
+	lineStart, lineEnd := 0, 0
+	addLine := func(line string) {
+		currentLines = append(currentLines, line)
+		if chunkStart < 0 {
+			chunkStart = lineStart
+		}
+		chunkEnd = lineEnd
+	}
+	flush := func() {
+		ret = append(ret, splitResult{
+			chunk: strings.Join(currentLines, "\n"),
+			meta:  deepCopyMap(recordedMetaMap),
+			start: chunkStart,
+			end:   chunkEnd,
+		})
+		currentLines = currentLines[:0]
+		chunkStart, chunkEnd = -1, -1
+	}
 	lines := strings.Split(text, "\n")
+	offset := 0
 	for _, line := range lines {
+		lineOffset := offset
+		offset += len(line) + 1
 		if len(line) == 0 {
 			continue
 		}
+		lineStart = lineOffset + len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+		lineEnd = lineOffset + len(strings.TrimRightFunc(line, unicode.IsSpace))
+		if lineEnd < lineStart {
+			lineEnd = lineStart
+		}
 		line = strings.TrimSpace(line)
 		if !bInCodeBlock {
 			if strings.HasPrefix(line, codeSep1) && strings.Count(line, codeSep1) == 1 {
@@ -162,7 +199,7 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 			}
 		}
 		if bInCodeBlock {
-			currentLines = append(currentLines, line)
+			addLine(line)
 			continue
 		}
 		// check if the line starts with headers
@@ -170,15 +207,11 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 		for header, name := range h.headers {
 			if strings.HasPrefix(line, header) && (len(line) == len(header) || line[len(header)] == ' ') {
 				if len(currentLines) > 0 {
-					ret = append(ret, splitResult{
-						chunk: strings.Join(currentLines, "\n"),
-						meta:  deepCopyMap(recordedMetaMap),
-					})
-					currentLines = currentLines[:0]
+					flush()
 				}
 
 				if !h.trimHeaders {
-					currentLines = append(currentLines, line)
+					addLine(line)
 				}
 
 				newLevel := len(header)
@@ -204,13 +237,10 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 			}
 		}
 		if !bNewHeader {
-			currentLines = append(currentLines, line)
+			addLine(line)
 		}
 	}
-	ret = append(ret, splitResult{
-		chunk: strings.Join(currentLines, "\n"),
-		meta:  deepCopyMap(recordedMetaMap),
-	})
+	flush()
 	return ret
 }
 
@@ -53,31 +53,31 @@ func TestMarkdownHeaderSplitter(t *testing.T) {
 			want: []*schema.Document{{
 				ID:      "id_part0",
 				Content: "```code1\ncode2\ncode3\n```",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "Header1",
-				},
+				}, 0, 4, 12, 36),
 			}, {
 				ID:      "id_part1",
 				Content: "Content1",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "Header1",
 					"Header2": "Header2",
-				},
+				}, 1, 4, 50, 58),
 			}, {
 				ID:      "id_part2",
 				Content: "Content2",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "Header1",
 					"Header2": "Header2",
 					"Header3": "Header3",
-				},
+				}, 2, 4, 76, 84),
 			}, {
 				ID:      "id_part3",
 				Content: "Content3",
-				MetaData: map[string]interface{}{
+				MetaData: withPosition(map[string]interface{}{
 					"Header1": "Header1",
 					"Header2": "Header4",
-				},
+				}, 3, 4, 101, 109),
 			}},
 		},
 	}
@@ -98,3 +98,12 @@ func TestMarkdownHeaderSplitter(t *testing.T) {
 		})
 	}
 }
+
+func withPosition(meta map[string]interface{}, index, total, start, end int) map[string]interface{} {
+	meta[MetaKeyParentID] = "id"
+	meta[MetaKeyChunkIndex] = index
+	meta[MetaKeyTotalChunks] = total
+	meta[MetaKeyStartOffset] = start
+	meta[MetaKeyEndOffset] = end
+	return meta
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyParentID is the ID of the document a chunk is split from, or a digest of its content if it has no ID.
+	MetaKeyParentID = "_parent_id"
+	// MetaKeyChunkIndex is the 0-based index of the chunk in its parent document.
+	MetaKeyChunkIndex = "_chunk_index"
+	// MetaKeyTotalChunks is the number of chunks the parent document is split into.
+	MetaKeyTotalChunks = "_total_chunks"
+	// MetaKeyStartOffset is the character (unicode code point) offset in the parent content where the chunk starts.
+	MetaKeyStartOffset = "_start_offset"
+	// MetaKeyEndOffset is the character offset in the parent content where the chunk ends, exclusive.
+	MetaKeyEndOffset = "_end_offset"
+)
+
+// parentID returns the ID of doc, or a digest of its content if it has no ID,
+// so chunks of different documents never share IDs.
+func parentID(doc *schema.Document) string {
+	if doc.ID != "" {
+		return doc.ID
+	}
+	sum := sha256.Sum256([]byte(doc.Content))
+	return hex.EncodeToString(sum[:16])
+}
+
+// chunkPositions records the position metadata of the chunks of one parent document.
+type chunkPositions struct {
+	parentID string
+	total    int
+	starts   runeCounter
+	ends     runeCounter
+}
+
+func newChunkPositions(doc *schema.Document, parentID string, total int) *chunkPositions {
+	return &chunkPositions{
+		parentID: parentID,
+		total:    total,
+		starts:   runeCounter{text: doc.Content},
+		ends:     runeCounter{text: doc.Content},
+	}
+}
+
+// set records the position of chunk index in meta, start and end are byte offsets in the parent content,
+// a negative start means the chunk can not be located in the parent.
+func (p *chunkPositions) set(meta map[string]any, index, start, end int) {
+	meta[MetaKeyParentID] = p.parentID
+	meta[MetaKeyChunkIndex] = index
+	meta[MetaKeyTotalChunks] = p.total
+	if start < 0 {
+		return
+	}
+	meta[MetaKeyStartOffset] = p.starts.at(start)
+	meta[MetaKeyEndOffset] = p.ends.at(end)
+}
+
+// runeCounter converts byte offsets to character offsets, incrementally as long as the offsets increase.
+type runeCounter struct {
+	text  string
+	bytes int
+	runes int
+}
+
+func (c *runeCounter) at(offset int) int {
+	if offset < c.bytes {
+		c.bytes, c.runes = 0, 0
+	}
+	c.runes += utf8.RuneCountInString(c.text[c.bytes:offset])
+	c.bytes = offset
+	return c.runes
+}
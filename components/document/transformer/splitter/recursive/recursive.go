@@ -19,7 +19,9 @@ package recursive
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
@@ -36,12 +38,13 @@ const (
 	KeepTypeEnd
 )
 
-// IDGenerator generates new IDs for split chunks
+// IDGenerator generates new IDs for split chunks,
+// originalID is the ID of the parent document, or a digest of its content if the parent has no ID.
 type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
 
-// defaultIDGenerator keeps the original ID
-func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
-	return originalID
+// defaultIDGenerator names chunks "<original ID>_<split index>"
+func defaultIDGenerator(ctx context.Context, originalID string, splitIndex int) string {
+	return originalID + "_" + strconv.Itoa(splitIndex)
 }
 
 type Config struct {
@@ -53,11 +56,15 @@ type Config struct {
 	// ["\n", ".", "?", "!"] by default.
 	Separators []string
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	// Token based length functions can be found in the splitter/tokenizer package.
 	LenFunc func(string) int
+	// CountRunes counts unicode code points instead of bytes when LenFunc is nil,
+	// so multi-byte text such as CJK is not split far smaller than ChunkSize.
+	CountRunes bool
 	// KeepType specifies if separator will be kept in split chunks. Discard separator by default.
 	KeepType KeepType
 	// IDGenerator is an optional function to generate new IDs for split chunks.
-	// If nil, the original document ID will be used for all splits.
+	// If nil, chunks are named "<original ID>_<split index>".
 	IDGenerator IDGenerator
 }
 
@@ -73,6 +80,9 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	lenFunc := config.LenFunc
 	if lenFunc == nil {
 		lenFunc = func(s string) int { return len(s) }
+		if config.CountRunes {
+			lenFunc = utf8.RuneCountInString
+		}
 	}
 	seps := config.Separators
 	if len(seps) == 0 {
@@ -105,11 +115,26 @@ func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
 		splits := s.splitText(ctx, doc.Content, s.separators)
+		parent := parentID(doc)
+		positions := newChunkPositions(doc, parent, len(splits))
+		// chunks are trimmed substrings of the parent in order, overlapping chunks start after the previous one.
+		cursor := 0
 		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]interface{}, 5)
+			}
+			start := strings.Index(doc.Content[cursor:], split)
+			if start >= 0 {
+				start += cursor
+				cursor = start + 1
+			}
+			positions.set(meta, i, start, start+len(split))
+
 			ret = append(ret, &schema.Document{
-				ID:       s.idGenerator(ctx, doc.ID, i),
+				ID:       s.idGenerator(ctx, parent, i),
 				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				MetaData: meta,
 			})
 		}
 	}
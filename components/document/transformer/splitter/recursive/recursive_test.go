@@ -35,6 +35,10 @@ func TestRecursiveSplitter(t *testing.T) {
 	input := []*schema.Document{
 		{Content: "1a23a45a67890c1a234b5678a90"},
 	}
+	cjkInput := []*schema.Document{
+		{Content: "一二三，四五六，七八"},
+	}
+	parent, cjkParent := parentID(input[0]), parentID(cjkInput[0])
 	tests := []struct {
 		name       string
 		args       args
@@ -53,13 +57,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23"},
-				{Content: "23a45"},
-				{Content: "67890"},
-				{Content: "1"},
-				{Content: "234"},
-				{Content: "5678"},
-				{Content: "90"},
+				{ID: parent + "_0", Content: "1a23"},
+				{ID: parent + "_1", Content: "23a45"},
+				{ID: parent + "_2", Content: "67890"},
+				{ID: parent + "_3", Content: "1"},
+				{ID: parent + "_4", Content: "234"},
+				{ID: parent + "_5", Content: "5678"},
+				{ID: parent + "_6", Content: "90"},
 			},
 		},
 		{
@@ -78,13 +82,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{ID: "_part0", Content: "1a23"},
-				{ID: "_part1", Content: "a45"},
-				{ID: "_part2", Content: "a67890"},
-				{ID: "_part3", Content: "c1"},
-				{ID: "_part4", Content: "a234"},
-				{ID: "_part5", Content: "b5678"},
-				{ID: "_part6", Content: "a90"},
+				{ID: parent + "_part0", Content: "1a23"},
+				{ID: parent + "_part1", Content: "a45"},
+				{ID: parent + "_part2", Content: "a67890"},
+				{ID: parent + "_part3", Content: "c1"},
+				{ID: parent + "_part4", Content: "a234"},
+				{ID: parent + "_part5", Content: "b5678"},
+				{ID: parent + "_part6", Content: "a90"},
 			},
 		},
 		{
@@ -100,13 +104,30 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23a"},
-				{Content: "45a"},
-				{Content: "67890c"},
-				{Content: "1a"},
-				{Content: "234b"},
-				{Content: "5678a"},
-				{Content: "90"},
+				{ID: parent + "_0", Content: "1a23a"},
+				{ID: parent + "_1", Content: "45a"},
+				{ID: parent + "_2", Content: "67890c"},
+				{ID: parent + "_3", Content: "1a"},
+				{ID: parent + "_4", Content: "234b"},
+				{ID: parent + "_5", Content: "5678a"},
+				{ID: parent + "_6", Content: "90"},
+			},
+		},
+		{
+			name: "count runes",
+			args: args{
+				ctx: ctx,
+				config: &Config{
+					ChunkSize:  3,
+					Separators: []string{"，"},
+					CountRunes: true,
+				},
+				input: cjkInput,
+			},
+			wantOutput: []*schema.Document{
+				{ID: cjkParent + "_0", Content: "一二三"},
+				{ID: cjkParent + "_1", Content: "四五六"},
+				{ID: cjkParent + "_2", Content: "七八"},
 			},
 		},
 	}
@@ -121,6 +142,15 @@ func TestRecursiveSplitter(t *testing.T) {
 				t.Errorf("Transform error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			parentContent := []rune(tt.args.input[0].Content)
+			for i, doc := range gotOutput {
+				start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+				if string(parentContent[start:end]) != doc.Content || doc.MetaData[MetaKeyChunkIndex] != i ||
+					doc.MetaData[MetaKeyTotalChunks] != len(gotOutput) || doc.MetaData[MetaKeyParentID] != parentID(tt.args.input[0]) {
+					t.Errorf("chunk %d position = %v, content %q", i, doc.MetaData, doc.Content)
+				}
+				doc.MetaData = nil
+			}
 			if !reflect.DeepEqual(gotOutput, tt.wantOutput) {
 				t.Errorf("splitText() gotOutput = %v, want %v", gotOutput, tt.wantOutput)
 			}
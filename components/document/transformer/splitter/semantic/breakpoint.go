@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semantic
+
+import (
+	"math"
+	"sort"
+)
+
+// BreakpointType specifies how split points are chosen from the differences between adjacent chunks.
+type BreakpointType string
+
+const (
+	// BreakpointPercentile splits where the difference is above the Percentile of differences.
+	BreakpointPercentile BreakpointType = "percentile"
+	// BreakpointStandardDeviation splits where the difference is more than BreakpointThreshold standard deviations above the mean.
+	BreakpointStandardDeviation BreakpointType = "standard_deviation"
+	// BreakpointInterquartile splits where the difference is more than BreakpointThreshold interquartile ranges above the mean,
+	// which is less sensitive to outliers than BreakpointStandardDeviation.
+	BreakpointInterquartile BreakpointType = "interquartile"
+	// BreakpointGradient splits where the gradient of differences is above its BreakpointThreshold percentile,
+	// which suits text whose chunks are all closely related, such as legal or medical documents.
+	BreakpointGradient BreakpointType = "gradient"
+)
+
+// breakpoints returns the indexes of the chunks starting a new split,
+// distances[i] is the difference between chunk i-1 and chunk i, distances[0] is unused.
+// Every breakpoint type splits where a value is greater than its threshold.
+func (s *splitter) breakpoints(distances []float64) []int {
+	values := distances[1:]
+	var threshold float64
+	switch s.breakpointType {
+	case BreakpointPercentile:
+		// at least one split, at the largest differences
+		splits := max(1, int((1-s.percentile)*float64(len(distances))))
+		if splits >= len(values) {
+			threshold = math.Inf(-1)
+		} else {
+			threshold = sortedCopy(values)[len(values)-splits-1]
+		}
+	case BreakpointStandardDeviation:
+		mean, std := meanStd(values)
+		threshold = mean + s.threshold*std
+	case BreakpointInterquartile:
+		mean, _ := meanStd(values)
+		sorted := sortedCopy(values)
+		threshold = mean + s.threshold*(percentile(sorted, 0.75)-percentile(sorted, 0.25))
+	case BreakpointGradient:
+		values = gradient(values)
+		threshold = percentile(sortedCopy(values), s.threshold)
+	}
+
+	var splitIndexes []int
+	for i, v := range values {
+		if v > threshold {
+			splitIndexes = append(splitIndexes, i+1)
+		}
+	}
+	return splitIndexes
+}
+
+func meanStd(values []float64) (mean, std float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		std += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / float64(len(values)))
+}
+
+func sortedCopy(values []float64) []float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// percentile interpolates linearly between the closest ranks of sorted values, p is in [0, 1].
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// gradient uses central differences in the interior and one-sided differences at the ends.
+func gradient(values []float64) []float64 {
+	n := len(values)
+	ret := make([]float64, n)
+	if n < 2 {
+		return ret
+	}
+	ret[0] = values[1] - values[0]
+	ret[n-1] = values[n-1] - values[n-2]
+	for i := 1; i < n-1; i++ {
+		ret[i] = (values[i+1] - values[i-1]) / 2
+	}
+	return ret
+}
@@ -20,20 +20,23 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
 )
 
-// IDGenerator generates new IDs for split chunks
+// IDGenerator generates new IDs for split chunks,
+// originalID is the ID of the parent document, or a digest of its content if the parent has no ID.
 type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
 
-// defaultIDGenerator keeps the original ID
-func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
-	return originalID
+// defaultIDGenerator names chunks "<original ID>_<split index>"
+func defaultIDGenerator(ctx context.Context, originalID string, splitIndex int) string {
+	return originalID + "_" + strconv.Itoa(splitIndex)
 }
 
 type Config struct {
@@ -46,11 +49,32 @@ type Config struct {
 	// Separators are sequentially used to split text. ["\n", ".", "?", "!"] by default.
 	Separators []string
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	// Token based length functions can be found in the splitter/tokenizer package.
 	LenFunc func(s string) int
-	// Percentile specifies the number of splitting. If the difference between two chunks is greater than X percentile, these two chunks will be split.
+	// CountRunes counts unicode code points instead of bytes when LenFunc is nil,
+	// so MinChunkSize and MaxChunkSize of multi-byte text such as CJK are measured in characters.
+	CountRunes bool
+	// MaxChunkSize is the maximum chunk's size, chunks larger than it are split recursively with Separators, then by characters.
+	// No limit by default.
+	MaxChunkSize int
+	// BreakpointType specifies how the split points are chosen from the differences between adjacent chunks.
+	// BreakpointPercentile by default.
+	BreakpointType BreakpointType
+	// Percentile specifies the number of splitting. If the difference between two chunks is greater than X percentile, these two chunks will be split,
+	// so a higher Percentile splits less often. There is at least one split, at the largest difference.
+	// Used by BreakpointPercentile, 0.9 by default.
 	Percentile float64
+	// BreakpointThreshold is the amount used by the other breakpoint types:
+	// the number of standard deviations above the mean for BreakpointStandardDeviation, 3 by default;
+	// the number of interquartile ranges above the mean for BreakpointInterquartile, 1.5 by default;
+	// the percentile of the gradient of differences for BreakpointGradient, 0.95 by default.
+	BreakpointThreshold float64
+	// BatchSize is the maximum number of texts in one embedding request, 16 by default.
+	BatchSize int
+	// Concurrency is the maximum number of embedding requests in flight for one document, 1 by default.
+	Concurrency int
 	// IDGenerator is an optional function to generate new IDs for split chunks.
-	// If nil, the original document ID will be used for all splits.
+	// If nil, chunks are named "<original ID>_<split index>".
 	IDGenerator IDGenerator
 }
 
@@ -61,6 +85,9 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	lenFunc := config.LenFunc
 	if lenFunc == nil {
 		lenFunc = func(s string) int { return len(s) }
+		if config.CountRunes {
+			lenFunc = utf8.RuneCountInString
+		}
 	}
 	seps := config.Separators
 	if len(seps) == 0 {
@@ -70,29 +97,72 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	if percentile == 0 {
 		percentile = 0.9
 	}
+	if config.MaxChunkSize < 0 {
+		return nil, fmt.Errorf("max chunk size must be greater than or equal to zero")
+	}
+	breakpointType := config.BreakpointType
+	if breakpointType == "" {
+		breakpointType = BreakpointPercentile
+	}
+	threshold := config.BreakpointThreshold
+	switch breakpointType {
+	case BreakpointPercentile:
+	case BreakpointStandardDeviation:
+		if threshold == 0 {
+			threshold = 3
+		}
+	case BreakpointInterquartile:
+		if threshold == 0 {
+			threshold = 1.5
+		}
+	case BreakpointGradient:
+		if threshold == 0 {
+			threshold = 0.95
+		}
+	default:
+		return nil, fmt.Errorf("unknown breakpoint type: %s", breakpointType)
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 16
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
 	idGenerator := config.IDGenerator
 	if idGenerator == nil {
 		idGenerator = defaultIDGenerator
 	}
 	return &splitter{
-		embedding:    config.Embedding,
-		bufferSize:   config.BufferSize,
-		minChunkSize: config.MinChunkSize,
-		separators:   seps,
-		lenFunc:      lenFunc,
-		percentile:   percentile,
-		idGenerator:  idGenerator,
+		embedding:      config.Embedding,
+		bufferSize:     config.BufferSize,
+		minChunkSize:   config.MinChunkSize,
+		maxChunkSize:   config.MaxChunkSize,
+		separators:     seps,
+		lenFunc:        lenFunc,
+		breakpointType: breakpointType,
+		percentile:     percentile,
+		threshold:      threshold,
+		batchSize:      batchSize,
+		concurrency:    concurrency,
+		idGenerator:    idGenerator,
 	}, nil
 }
 
 type splitter struct {
-	embedding    embedding.Embedder
-	bufferSize   int
-	minChunkSize int
-	separators   []string
-	lenFunc      func(s string) int
-	percentile   float64
-	idGenerator  IDGenerator
+	embedding      embedding.Embedder
+	bufferSize     int
+	minChunkSize   int
+	maxChunkSize   int
+	separators     []string
+	lenFunc        func(s string) int
+	breakpointType BreakpointType
+	percentile     float64
+	threshold      float64
+	batchSize      int
+	concurrency    int
+	idGenerator    IDGenerator
 }
 
 func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
@@ -102,11 +172,22 @@ func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts
 		if err != nil {
 			return nil, fmt.Errorf("split document[%s] fail: %w", doc.ID, err)
 		}
+		parent := parentID(doc)
+		positions := newChunkPositions(doc, parent, len(splits))
+		// chunks are consecutive pieces of the parent
+		offset := 0
 		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]interface{}, 5)
+			}
+			positions.set(meta, i, offset, offset+len(split))
+			offset += len(split)
+
 			ret = append(ret, &schema.Document{
-				ID:       s.idGenerator(ctx, doc.ID, i),
+				ID:       s.idGenerator(ctx, parent, i),
 				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				MetaData: meta,
 			})
 		}
 	}
@@ -114,17 +195,28 @@ func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts
 }
 
 func (s *splitter) splitText(ctx context.Context, text string, separators []string) ([]string, error) {
+	chunks, err := s.semanticSplit(ctx, text, separators)
+	if err != nil {
+		return nil, err
+	}
+	if s.maxChunkSize <= 0 {
+		return chunks, nil
+	}
+
+	ret := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		ret = append(ret, s.limitSize(chunk, separators)...)
+	}
+	return ret, nil
+}
+
+func (s *splitter) semanticSplit(ctx context.Context, text string, separators []string) ([]string, error) {
 	texts := []string{text}
 	// split
 	for i := range s.separators {
 		texts = splitTexts(texts, separators[i])
 	}
 
-	sentencesLength := make([]int, len(texts))
-	for i := range texts {
-		sentencesLength[i] = s.lenFunc(texts[i])
-	}
-
 	if len(texts) == 1 {
 		return texts, nil
 	}
@@ -143,14 +235,10 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 	}
 
 	// embedding
-	var vectors [][]float64
-	v, err := s.embedding.EmbedStrings(ctx, combinedSentences)
+	vectors, err := s.embed(ctx, combinedSentences)
 	if err != nil {
 		return nil, err
 	}
-	for i := range v {
-		vectors = append(vectors, v[i])
-	}
 
 	// cosine distances
 	distances := make([]float64, len(texts))
@@ -158,18 +246,12 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 		distances[i] = 1 - cosine(vectors[i-1], vectors[i])
 	}
 
-	threshold := calThreshold(distances, s.percentile)
-	var splitIndexes []int
-	for i := 1; i < len(distances); i++ {
-		if distances[i] <= threshold {
-			splitIndexes = append(splitIndexes, i)
-		}
-	}
+	splitIndexes := s.breakpoints(distances)
 	var ret []string
 	var startIndex int
 	for i := range splitIndexes {
 		chunk := strings.Join(texts[startIndex:splitIndexes[i]], "")
-		if len(chunk) < s.minChunkSize {
+		if s.lenFunc(chunk) < s.minChunkSize {
 			continue
 		}
 		ret = append(ret, chunk)
@@ -179,6 +261,124 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 	return ret, nil
 }
 
+// embed embeds texts in batches of batchSize, with at most concurrency requests in flight.
+func (s *splitter) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		vectors = make([][]float64, len(texts))
+		sem     = make(chan struct{}, s.concurrency)
+		wg      sync.WaitGroup
+		once    sync.Once
+		embErr  error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			embErr = err
+			cancel()
+		})
+	}
+
+	for start := 0; start < len(texts); start += s.batchSize {
+		end := start + s.batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			v, err := s.embedding.EmbedStrings(ctx, texts[start:end])
+			if err != nil {
+				fail(err)
+				return
+			}
+			if len(v) != end-start {
+				fail(fmt.Errorf("embedding returns %d vectors for %d texts", len(v), end-start))
+				return
+			}
+			copy(vectors[start:end], v)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if embErr != nil {
+		return nil, embErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+// limitSize splits a chunk larger than maxChunkSize recursively with separators, then by characters,
+// merging adjacent pieces as long as they fit. The pieces keep their separators, so they join back to chunk.
+func (s *splitter) limitSize(chunk string, separators []string) []string {
+	if s.lenFunc(chunk) <= s.maxChunkSize {
+		return []string{chunk}
+	}
+
+	var pieces []string
+	for i, sep := range separators {
+		if sep != "" && strings.Contains(chunk, sep) {
+			pieces = strings.SplitAfter(chunk, sep)
+			separators = separators[i+1:]
+			break
+		}
+	}
+	if pieces == nil {
+		return s.splitRunes(chunk)
+	}
+
+	var ret []string
+	cur := ""
+	for _, piece := range pieces {
+		if piece == "" {
+			continue
+		}
+		if cur != "" && s.lenFunc(cur+piece) <= s.maxChunkSize {
+			cur += piece
+			continue
+		}
+		if cur != "" {
+			ret = append(ret, s.limitSize(cur, separators)...)
+		}
+		cur = piece
+	}
+	if cur != "" {
+		ret = append(ret, s.limitSize(cur, separators)...)
+	}
+	return ret
+}
+
+// splitRunes splits text into the longest pieces fitting maxChunkSize, at least one character each.
+func (s *splitter) splitRunes(text string) []string {
+	var ret []string
+	start := 0
+	for i, r := range text {
+		end := i + utf8.RuneLen(r)
+		if i > start && s.lenFunc(text[start:end]) > s.maxChunkSize {
+			ret = append(ret, text[start:i])
+			start = i
+		}
+	}
+	if start < len(text) {
+		ret = append(ret, text[start:])
+	}
+	return ret
+}
+
 func (s *splitter) GetType() string {
 	return "SemanticSplitter"
 }
@@ -206,17 +406,6 @@ func splitTexts(texts []string, sep string) []string {
 	return ret
 }
 
-func calThreshold(distances []float64, percentile float64) float64 {
-	sorted := make([]float64, len(distances))
-	copy(sorted, distances)
-	sort.Float64s(sorted)
-	idx := int((1 - percentile) * float64(len(sorted)))
-	if idx == 0 {
-		idx = 1
-	}
-	return sorted[idx]
-}
-
 func deepCopyMap(m map[string]interface{}) map[string]interface{} {
 	if m == nil {
 		return nil
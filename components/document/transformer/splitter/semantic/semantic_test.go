@@ -18,13 +18,19 @@ package semantic
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/cloudwego/eino/components/document"
-	"github.com/cloudwego/eino/components/embedding"
-	"github.com/cloudwego/eino/schema"
 	"math/rand/v2"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 )
 
 type randomEmbedding struct {
@@ -118,6 +124,21 @@ func TestSemanticSplitter(t *testing.T) {
 			}},
 			outputLen: 6,
 		},
+		{
+			name: "min chunk size measured by runes",
+			config: &Config{
+				Embedding:    &randomEmbedding{vecLen: 5},
+				BufferSize:   1,
+				MinChunkSize: 9,
+				Separators:   []string{"."},
+				CountRunes:   true,
+				Percentile:   0.00001,
+			},
+			input: []*schema.Document{{
+				Content: "一二三.一二三.一二三.一二三.一二三.一二三",
+			}},
+			outputLen: 2,
+		},
 	}
 	ctx := context.Background()
 	for _, tt := range tests {
@@ -134,7 +155,190 @@ func TestSemanticSplitter(t *testing.T) {
 				if !reflect.DeepEqual(len(got), tt.outputLen) {
 					t.Errorf("Transform() got = %v, want %v", got, tt.outputLen)
 				}
+				parent := []rune(tt.input[0].Content)
+				for j, doc := range got {
+					start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+					if string(parent[start:end]) != doc.Content || doc.MetaData[MetaKeyChunkIndex] != j || doc.MetaData[MetaKeyTotalChunks] != len(got) {
+						t.Errorf("chunk %d position = %v, content %q", j, doc.MetaData, doc.Content)
+					}
+				}
 			}
 		})
 	}
 }
+
+// topicEmbedding embeds texts starting with "a" and other texts to orthogonal vectors, recording the batches.
+type topicEmbedding struct {
+	mu       sync.Mutex
+	batches  []int
+	inFlight int
+	maxIn    int
+	err      error
+	short    bool
+}
+
+func (e *topicEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.mu.Lock()
+	e.batches = append(e.batches, len(texts))
+	e.inFlight++
+	if e.inFlight > e.maxIn {
+		e.maxIn = e.inFlight
+	}
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.inFlight--
+		e.mu.Unlock()
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	if e.err != nil {
+		return nil, e.err
+	}
+	ret := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		if strings.HasPrefix(text, "a") {
+			ret = append(ret, []float64{1, 0})
+		} else {
+			ret = append(ret, []float64{0, 1})
+		}
+	}
+	if e.short {
+		ret = ret[1:]
+	}
+	return ret, nil
+}
+
+func TestSemanticSplitterBreakpoints(t *testing.T) {
+	ctx := context.Background()
+	content := strings.Repeat("a.", 10) + strings.Repeat("b.", 9) + "b"
+
+	for _, bt := range []BreakpointType{BreakpointPercentile, BreakpointStandardDeviation, BreakpointInterquartile, BreakpointGradient} {
+		t.Run(string(bt), func(t *testing.T) {
+			emb := &topicEmbedding{}
+			s, err := NewSplitter(ctx, &Config{
+				Embedding:      emb,
+				Separators:     []string{"."},
+				BreakpointType: bt,
+				BatchSize:      3,
+				Concurrency:    2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(docs) != 2 || docs[0].Content+docs[1].Content != content {
+				t.Fatalf("Transform() got %v", docs)
+			}
+			if bt != BreakpointGradient && docs[0].Content != strings.Repeat("a.", 10) {
+				t.Errorf("Transform() first chunk = %q", docs[0].Content)
+			}
+
+			sort.Ints(emb.batches)
+			if !reflect.DeepEqual(emb.batches, []int{2, 3, 3, 3, 3, 3, 3}) || emb.maxIn > 2 {
+				t.Errorf("batches = %v, max in flight %d", emb.batches, emb.maxIn)
+			}
+		})
+	}
+}
+
+// letterEmbedding embeds a text by its first letter, the difference is 0.2 between "a" and "b", 0.4 between "b" and "c".
+type letterEmbedding struct{}
+
+func (letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := map[byte][]float64{'a': {1, 0}, 'b': {0.8, 0.6}, 'c': {0, 1}}
+	ret := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		ret = append(ret, vectors[text[0]])
+	}
+	return ret, nil
+}
+
+func TestSemanticSplitterPercentile(t *testing.T) {
+	ctx := context.Background()
+	content := "a.a.a.b.b.b.c.c.c"
+
+	tests := []struct {
+		percentile float64
+		want       []string
+	}{
+		// a single split at the largest difference
+		{percentile: 0.9, want: []string{"a.a.a.b.b.b.", "c.c.c"}},
+		// 25% of 9 chunks, the two largest differences
+		{percentile: 0.75, want: []string{"a.a.a.", "b.b.b.", "c.c.c"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.percentile), func(t *testing.T) {
+			s, err := NewSplitter(ctx, &Config{
+				Embedding:  letterEmbedding{},
+				Separators: []string{"."},
+				Percentile: tt.percentile,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, doc := range docs {
+				got = append(got, doc.Content)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Transform() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemanticSplitterMaxChunkSize(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:      &topicEmbedding{},
+		Separators:     []string{".", " "},
+		BreakpointType: BreakpointStandardDeviation,
+		MaxChunkSize:   10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := s.Transform(ctx, []*schema.Document{{Content: "one two three four five.abcdefghijklmnop"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var contents []string
+	for _, doc := range docs {
+		contents = append(contents, doc.Content)
+	}
+	want := []string{"one two ", "three ", "four five.", "abcdefghij", "klmnop"}
+	if !reflect.DeepEqual(contents, want) {
+		t.Errorf("Transform() got %q, want %q", contents, want)
+	}
+	if docs[3].MetaData[MetaKeyStartOffset] != 24 {
+		t.Errorf("start offset = %v", docs[3].MetaData[MetaKeyStartOffset])
+	}
+}
+
+func TestSemanticSplitterErrors(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewSplitter(ctx, &Config{Embedding: &topicEmbedding{}, BreakpointType: "unknown"})
+	if err == nil {
+		t.Error("NewSplitter() expects error of unknown breakpoint type")
+	}
+
+	for _, emb := range []*topicEmbedding{{err: errors.New("rate limited")}, {short: true}} {
+		s, err := NewSplitter(ctx, &Config{Embedding: emb, Separators: []string{"."}, BatchSize: 2, Concurrency: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.Transform(ctx, []*schema.Document{{Content: "a.b.c.d.e.f.g"}})
+		if err == nil {
+			t.Error("Transform() expects embedding error")
+		}
+	}
+}
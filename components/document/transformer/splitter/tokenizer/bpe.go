@@ -0,0 +1,210 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Encoding names the pre-tokenization rules of a BPE vocabulary.
+type Encoding string
+
+const (
+	// EncodingCl100k is used by gpt-4, gpt-3.5-turbo and text-embedding-3 models.
+	EncodingCl100k Encoding = "cl100k_base"
+	// EncodingO200k is used by gpt-4o and newer models.
+	EncodingO200k Encoding = "o200k_base"
+)
+
+type BPEConfig struct {
+	// Encoding selects the pre-tokenization rules matching the vocabulary, default EncodingCl100k.
+	Encoding Encoding
+	// VocabFile is the path of a vocabulary in tiktoken format, one "<base64 token> <rank>" per line,
+	// e.g. a local copy of cl100k_base.tiktoken or o200k_base.tiktoken.
+	VocabFile string
+	// Vocab is read instead of VocabFile when set.
+	Vocab io.Reader
+}
+
+// BPE is a byte pair encoding tokenizer compatible with tiktoken vocabularies, it is safe for concurrent use.
+type BPE struct {
+	ranks map[string]int
+	split func(string) []string
+}
+
+var _ Tokenizer = (*BPE)(nil)
+
+// NewBPE creates a BPE tokenizer from a local vocabulary, no network access is needed.
+func NewBPE(config *BPEConfig) (*BPE, error) {
+	if config == nil {
+		return nil, errors.New("bpe config is nil")
+	}
+
+	b := &BPE{}
+	switch config.Encoding {
+	case "", EncodingCl100k:
+		b.split = splitCl100k
+	case EncodingO200k:
+		b.split = splitO200k
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", config.Encoding)
+	}
+
+	r := config.Vocab
+	if r == nil {
+		if config.VocabFile == "" {
+			return nil, errors.New("bpe vocab or vocab file is required")
+		}
+		f, err := os.Open(config.VocabFile)
+		if err != nil {
+			return nil, fmt.Errorf("open vocab file failed: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ranks, err := loadRanks(r)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < 256; i++ {
+		if _, ok := ranks[string([]byte{byte(i)})]; !ok {
+			return nil, fmt.Errorf("vocab misses single byte token %#x", i)
+		}
+	}
+	b.ranks = ranks
+
+	return b, nil
+}
+
+func loadRanks(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int, 1<<17)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		token, rank, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid vocab line %d", lineNo)
+		}
+		tb, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("decode token of vocab line %d failed: %w", lineNo, err)
+		}
+		rv, err := strconv.Atoi(strings.TrimSpace(rank))
+		if err != nil {
+			return nil, fmt.Errorf("parse rank of vocab line %d failed: %w", lineNo, err)
+		}
+		ranks[string(tb)] = rv
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read vocab failed: %w", err)
+	}
+
+	return ranks, nil
+}
+
+func (b *BPE) Encode(text string) []int {
+	var tokens []int
+	for _, piece := range b.split(text) {
+		if rank, ok := b.ranks[piece]; ok {
+			tokens = append(tokens, rank)
+			continue
+		}
+		tokens = b.bytePairEncode(piece, tokens)
+	}
+	return tokens
+}
+
+func (b *BPE) Count(text string) int {
+	count := 0
+	for _, piece := range b.split(text) {
+		if _, ok := b.ranks[piece]; ok {
+			count++
+			continue
+		}
+		count += len(b.bytePairMerge(piece)) - 1
+	}
+	return count
+}
+
+func (b *BPE) bytePairEncode(piece string, tokens []int) []int {
+	parts := b.bytePairMerge(piece)
+	for i := 0; i < len(parts)-1; i++ {
+		tokens = append(tokens, b.ranks[piece[parts[i].start:parts[i+1].start]])
+	}
+	return tokens
+}
+
+type bpePart struct {
+	start int
+	rank  int
+}
+
+// bytePairMerge repeatedly merges the adjacent pair with the lowest rank, returning the boundaries of the tokens,
+// the last part marks the end of the piece.
+func (b *BPE) bytePairMerge(piece string) []bpePart {
+	parts := make([]bpePart, len(piece)+1)
+	for i := range parts {
+		parts[i] = bpePart{start: i, rank: math.MaxInt}
+	}
+
+	pairRank := func(i int) int {
+		if i+2 < len(parts) {
+			if rank, ok := b.ranks[piece[parts[i].start:parts[i+2].start]]; ok {
+				return rank
+			}
+		}
+		return math.MaxInt
+	}
+
+	for i := 0; i < len(parts)-2; i++ {
+		parts[i].rank = pairRank(i)
+	}
+
+	for len(parts) > 1 {
+		minIdx, minRank := -1, math.MaxInt
+		for i := 0; i < len(parts)-1; i++ {
+			if parts[i].rank < minRank {
+				minIdx, minRank = i, parts[i].rank
+			}
+		}
+		if minIdx < 0 {
+			break
+		}
+
+		parts = append(parts[:minIdx+1], parts[minIdx+2:]...)
+		parts[minIdx].rank = pairRank(minIdx)
+		if minIdx > 0 {
+			parts[minIdx-1].rank = pairRank(minIdx - 1)
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"unicode"
+)
+
+// The pre-tokenizers below split text the same way as the regular expressions of the tiktoken encodings,
+// written by hand because the look-ahead in those expressions is not supported by the regexp package.
+
+// splitCl100k implements
+//
+//	(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func splitCl100k(text string) []string {
+	return split(text, func(rs []rune, i int) int {
+		if c := contraction(rs, i); c > 0 {
+			return i + c
+		}
+		if j := i + optionalPrefix(rs, i); j < len(rs) && unicode.IsLetter(rs[j]) {
+			return skip(rs, j, unicode.IsLetter)
+		}
+		return matchCommon(rs, i, false)
+	})
+}
+
+// splitO200k implements
+//
+//	[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?|
+//	[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?|
+//	\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func splitO200k(text string) []string {
+	return split(text, func(rs []rune, i int) int {
+		prefix := optionalPrefix(rs, i)
+
+		for _, p := range []int{prefix, 0} {
+			if e := matchUpperLower(rs, i+p); e > 0 {
+				return e + contraction(rs, e)
+			}
+			if p == 0 {
+				break
+			}
+		}
+		for _, p := range []int{prefix, 0} {
+			if j := i + p; j < len(rs) && isUpper(rs[j]) {
+				e := skip(rs, skip(rs, j, isUpper), isLower)
+				return e + contraction(rs, e)
+			}
+			if p == 0 {
+				break
+			}
+		}
+		return matchCommon(rs, i, true)
+	})
+}
+
+func split(text string, match func(rs []rune, i int) int) []string {
+	rs := []rune(text)
+	pieces := make([]string, 0, len(rs)/4+1)
+	for i := 0; i < len(rs); {
+		e := match(rs, i)
+		if e <= i {
+			e = i + 1
+		}
+		pieces = append(pieces, string(rs[i:e]))
+		i = e
+	}
+	return pieces
+}
+
+// matchCommon matches the number, punctuation and whitespace alternatives shared by both encodings.
+func matchCommon(rs []rune, i int, slashAfterPunct bool) int {
+	n := len(rs)
+
+	// \p{N}{1,3}
+	if unicode.IsNumber(rs[i]) {
+		e := i + 1
+		for e < n && e < i+3 && unicode.IsNumber(rs[e]) {
+			e++
+		}
+		return e
+	}
+
+	// ' ?[^\s\p{L}\p{N}]+[\r\n]*', o200k also allows '/' in the trailing part.
+	j := i
+	if rs[j] == ' ' && j+1 < n && isPunct(rs[j+1]) {
+		j++
+	}
+	if isPunct(rs[j]) {
+		e := skip(rs, j, isPunct)
+		return skip(rs, e, func(r rune) bool {
+			return isNewline(r) || (slashAfterPunct && r == '/')
+		})
+	}
+
+	if unicode.IsSpace(rs[i]) {
+		k := skip(rs, i, unicode.IsSpace)
+		// \s*[\r\n]+ ends at the last newline of the whitespace run.
+		for p := k - 1; p >= i; p-- {
+			if isNewline(rs[p]) {
+				return p + 1
+			}
+		}
+		// \s+(?!\S) leaves the last space to the following word, \s+ otherwise.
+		if k < n && k-i > 1 {
+			return k - 1
+		}
+		return k
+	}
+
+	return i + 1
+}
+
+// matchUpperLower matches [upper]*[lower]+ at j with backtracking, returning -1 when it fails.
+func matchUpperLower(rs []rune, j int) int {
+	if j >= len(rs) {
+		return -1
+	}
+	u := skip(rs, j, isUpper)
+	if u < len(rs) && isLower(rs[u]) {
+		return skip(rs, u, isLower)
+	}
+	for k := u - 1; k >= j; k-- {
+		if isLower(rs[k]) {
+			return skip(rs, k, isLower)
+		}
+	}
+	return -1
+}
+
+// optionalPrefix returns 1 if rs[i] can be the leading [^\r\n\p{L}\p{N}] of a word.
+func optionalPrefix(rs []rune, i int) int {
+	r := rs[i]
+	if isNewline(r) || unicode.IsLetter(r) || unicode.IsNumber(r) {
+		return 0
+	}
+	return 1
+}
+
+// contraction returns the length of a (?i:'s|'t|'re|'ve|'m|'ll|'d) match at i, or 0.
+func contraction(rs []rune, i int) int {
+	if i >= len(rs) || rs[i] != '\'' {
+		return 0
+	}
+	for _, suffix := range []string{"s", "t", "re", "ve", "m", "ll", "d"} {
+		if i+len(suffix) >= len(rs) {
+			continue
+		}
+		ok := true
+		for k, c := range suffix {
+			if unicode.ToLower(rs[i+1+k]) != c {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return 1 + len(suffix)
+		}
+	}
+	return 0
+}
+
+func skip(rs []rune, i int, f func(rune) bool) int {
+	for i < len(rs) && f(rs[i]) {
+		i++
+	}
+	return i
+}
+
+func isNewline(r rune) bool {
+	return r == '\r' || r == '\n'
+}
+
+func isPunct(r rune) bool {
+	return !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+func isUpper(r rune) bool {
+	return unicode.In(r, unicode.Lu, unicode.Lt, unicode.Lm, unicode.Lo, unicode.M)
+}
+
+func isLower(r rune) bool {
+	return unicode.In(r, unicode.Ll, unicode.Lm, unicode.Lo, unicode.M)
+}
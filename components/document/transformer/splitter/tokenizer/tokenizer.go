@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tokenizer provides offline tokenizers and length functions for the splitters,
+// so that chunk sizes can be expressed in model tokens instead of bytes.
+package tokenizer
+
+import (
+	"unicode/utf8"
+)
+
+// Tokenizer converts text to token ids.
+type Tokenizer interface {
+	// Encode returns the token ids of text, special tokens are treated as plain text.
+	Encode(text string) []int
+	// Count returns the number of tokens of text, which equals len(Encode(text)).
+	Count(text string) int
+}
+
+// LenFunc returns a length function counting tokens with t, to be used as the LenFunc of a splitter.
+func LenFunc(t Tokenizer) func(string) int {
+	return t.Count
+}
+
+// RuneCount is a length function counting unicode code points,
+// which keeps multi-byte text such as CJK from being split far smaller than intended.
+func RuneCount(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// ByteCount is a length function counting bytes, the default of the splitters.
+func ByteCount(s string) int {
+	return len(s)
+}
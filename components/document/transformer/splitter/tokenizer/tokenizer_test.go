@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testVocab has all single bytes plus a few merges spelling "hello" and " world".
+func testVocab() string {
+	var sb strings.Builder
+	add := func(token string, rank int) {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank))
+	}
+	for i := 0; i < 256; i++ {
+		add(string([]byte{byte(i)}), i)
+	}
+	for i, token := range []string{"he", "ll", "hell", "hello", " w", "or", " wor", "ld", " world"} {
+		add(token, 256+i)
+	}
+	return sb.String()
+}
+
+func TestBPE(t *testing.T) {
+	b, err := NewBPE(&BPEConfig{Vocab: strings.NewReader(testVocab())})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{259, 264}, b.Encode("hello world"))
+	assert.Equal(t, []int{258, 'x'}, b.Encode("hellx"))
+	assert.Equal(t, []int{261, 263}, b.Encode("orld"))
+	assert.Equal(t, []int{'o', 'w', 'l'}, b.Encode("owl"))
+	assert.Equal(t, 0, b.Count(""))
+
+	for _, text := range []string{"hello world", "hellx", "orld", "你好，世界", "hello hello\n\n  world!!"} {
+		assert.Equal(t, len(b.Encode(text)), b.Count(text), text)
+	}
+	// unknown multi-byte text falls back to byte tokens.
+	assert.Equal(t, len("你好"), b.Count("你好"))
+
+	lenFunc := LenFunc(b)
+	assert.Equal(t, 2, lenFunc("hello world"))
+
+	path := filepath.Join(t.TempDir(), "test.tiktoken")
+	assert.NoError(t, os.WriteFile(path, []byte(testVocab()), 0o644))
+	b, err = NewBPE(&BPEConfig{VocabFile: path, Encoding: EncodingO200k})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{259, 264}, b.Encode("hello world"))
+
+	_, err = NewBPE(&BPEConfig{Vocab: strings.NewReader("aGVsbG8= 0\n")})
+	assert.ErrorContains(t, err, "single byte")
+	_, err = NewBPE(&BPEConfig{Vocab: strings.NewReader("not-base64 0\n")})
+	assert.Error(t, err)
+	_, err = NewBPE(&BPEConfig{VocabFile: path, Encoding: "p50k_base"})
+	assert.Error(t, err)
+	_, err = NewBPE(&BPEConfig{})
+	assert.Error(t, err)
+}
+
+func TestSplitCl100k(t *testing.T) {
+	cases := map[string][]string{
+		"Hello world":       {"Hello", " world"},
+		"I'm here, they'LL": {"I", "'m", " here", ",", " they", "'LL"},
+		"12345 apples":      {"123", "45", " apples"},
+		"hi!\n\nthere":      {"hi", "!\n\n", "there"},
+		"a  b":              {"a", " ", " b"},
+		"end   ":            {"end", "   "},
+		"line\n  next":      {"line", "\n", " ", " next"},
+		"你好，世界":             {"你好", "，世界"},
+		"$100 (approx.)":    {"$", "100", " (", "approx", ".)"},
+	}
+	for text, want := range cases {
+		assert.Equal(t, want, splitCl100k(text), text)
+	}
+}
+
+func TestSplitO200k(t *testing.T) {
+	cases := map[string][]string{
+		"Hello world":     {"Hello", " world"},
+		"HELLO world":     {"HELLO", " world"},
+		"CamelCase":       {"Camel", "Case"},
+		"don't stop":      {"don't", " stop"},
+		"path/to/file\n":  {"path", "/to", "/file", "\n"},
+		"12345":           {"123", "45"},
+		"a  b":            {"a", " ", " b"},
+		"a // comment\n/": {"a", " //", " comment", "\n", "/"},
+	}
+	for text, want := range cases {
+		assert.Equal(t, want, splitO200k(text), text)
+	}
+}
+
+func TestLenFuncs(t *testing.T) {
+	assert.Equal(t, 5, RuneCount("你好，世界"))
+	assert.Equal(t, 15, ByteCount("你好，世界"))
+}
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
@@ -31,3 +32,50 @@ type Cacher interface {
 	// If the value is not of type []float64, it returns an error.
 	Get(ctx context.Context, key string) ([]float64, bool, error)
 }
+
+// BatchCacher is an optional extension of [Cacher] for backends that can read and write
+// many keys in one round trip. The [Embedder] uses it when the configured cacher implements it.
+type BatchCacher interface {
+	Cacher
+
+	// BatchSet stores values[i] under keys[i], keys and values must have the same length.
+	BatchSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error
+
+	// BatchGet retrieves the values of the given keys, the returned slices are aligned with keys
+	// and found[i] reports whether keys[i] exists.
+	BatchGet(ctx context.Context, keys []string) (values [][]float64, found []bool, err error)
+}
+
+// batchGet reads keys with a single BatchGet if supported, otherwise one Get per key.
+func batchGet(ctx context.Context, c Cacher, keys []string) ([][]float64, []bool, error) {
+	if bc, ok := c.(BatchCacher); ok {
+		return bc.BatchGet(ctx, keys)
+	}
+
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	for i, key := range keys {
+		value, ok, err := c.Get(ctx, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i], found[i] = value, ok
+	}
+	return values, found, nil
+}
+
+// batchSet writes keys with a single BatchSet if supported, otherwise one Set per key,
+// a failed Set does not stop the remaining ones.
+func batchSet(ctx context.Context, c Cacher, keys []string, values [][]float64, expire time.Duration) error {
+	if bc, ok := c.(BatchCacher); ok {
+		return bc.BatchSet(ctx, keys, values, expire)
+	}
+
+	var errs []error
+	for i, key := range keys {
+		if err := c.Set(ctx, key, values[i], expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
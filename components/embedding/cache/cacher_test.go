@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("evict least recently used", func(t *testing.T) {
+		c := NewLRUCacher(2)
+		require.NoError(t, c.Set(ctx, "a", []float64{1}, 0))
+		require.NoError(t, c.Set(ctx, "b", []float64{2}, 0))
+
+		// touch a, so b becomes the least recently used
+		_, ok, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, c.Set(ctx, "c", []float64{3}, 0))
+		assert.Equal(t, 2, c.Len())
+
+		values, found, err := c.BatchGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []bool{true, false, true}, found)
+		assert.Equal(t, [][]float64{{1}, nil, {3}}, values)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		now := time.Now()
+		c := NewLRUCacher(0)
+		c.now = func() time.Time { return now }
+
+		require.NoError(t, c.BatchSet(ctx, []string{"a", "b"}, [][]float64{{1}, {2}}, time.Minute))
+		require.NoError(t, c.Set(ctx, "c", []float64{3}, 0))
+
+		now = now.Add(time.Minute)
+		_, found, err := c.BatchGet(ctx, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Equal(t, []bool{false, false, true}, found)
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		c := NewLRUCacher(1)
+		require.NoError(t, c.Set(ctx, "a", []float64{1}, 0))
+		require.NoError(t, c.Set(ctx, "a", []float64{2}, 0))
+
+		value, ok, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{2}, value)
+	})
+
+	t.Run("values are copied", func(t *testing.T) {
+		c := NewLRUCacher(1)
+		value := []float64{1}
+		require.NoError(t, c.Set(ctx, "a", value, 0))
+		value[0] = 2
+
+		got, ok, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{1}, got)
+
+		got[0] = 3
+		got, _, err = c.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1}, got)
+	})
+}
+
+func TestTieredCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("read through and backfill", func(t *testing.T) {
+		front, back := NewLRUCacher(10), NewLRUCacher(10)
+		c := NewTieredCacher(front, back)
+
+		require.NoError(t, back.BatchSet(ctx, []string{"a", "b"}, [][]float64{{1}, {2}}, time.Minute))
+		require.NoError(t, front.Set(ctx, "c", []float64{3}, 0))
+
+		values, found, err := c.BatchGet(ctx, []string{"a", "c", "d"})
+		require.NoError(t, err)
+		assert.Equal(t, []bool{true, true, false}, found)
+		assert.Equal(t, [][]float64{{1}, {3}, nil}, values)
+		assert.Equal(t, 2, front.Len())
+
+		value, ok, err := c.Get(ctx, "b")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{2}, value)
+		assert.Equal(t, 3, front.Len())
+	})
+
+	t.Run("write to all tiers", func(t *testing.T) {
+		front, back := NewLRUCacher(10), new(mockCacher)
+		c := NewTieredCacher(front, back)
+
+		back.On("Set", mock.Anything, "a", []float64{1}, time.Minute).Return(nil)
+		back.On("Set", mock.Anything, "b", []float64{2}, time.Minute).Return(errors.New("set error"))
+
+		require.NoError(t, c.Set(ctx, "a", []float64{1}, time.Minute))
+		assert.Error(t, c.BatchSet(ctx, []string{"a", "b"}, [][]float64{{1}, {2}}, time.Minute))
+		assert.Equal(t, 2, front.Len())
+		back.AssertExpectations(t)
+	})
+
+	t.Run("lower tier error", func(t *testing.T) {
+		back := new(mockCacher)
+		c := NewTieredCacher(NewLRUCacher(10), back)
+
+		back.On("Get", mock.Anything, "a").Return(nil, false, errors.New("get error"))
+
+		_, _, err := c.BatchGet(ctx, []string{"a"})
+		assert.Error(t, err)
+		_, _, err = c.Get(ctx, "a")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidEncoding is returned by a [Codec] decoding data it did not encode.
+var ErrInvalidEncoding = errors.New("embedding/cache: invalid encoding")
+
+// Codec converts embeddings to and from the bytes stored by a cacher.
+//
+// The built-in codecs prefix their output with a format tag, so data written by another codec is rejected
+// with [ErrInvalidEncoding] instead of being misread. The cachers treat such data as a miss, overwritten by the next Set.
+type Codec interface {
+	Encode(value []float64) ([]byte, error)
+	Decode(data []byte) ([]float64, error)
+}
+
+const (
+	formatFloat64 byte = iota + 1
+	formatFloat32
+	formatFloat16
+	formatInt8
+)
+
+// NewFloat64Codec returns a lossless [Codec] storing 8 bytes per dimension.
+func NewFloat64Codec() Codec {
+	return float64Codec{}
+}
+
+// NewFloat32Codec returns a [Codec] storing 4 bytes per dimension,
+// lossless for embeddings that are float32 at the source, which most providers return.
+func NewFloat32Codec() Codec {
+	return float32Codec{}
+}
+
+// NewFloat16Codec returns a [Codec] storing 2 bytes per dimension as IEEE 754 half precision,
+// which keeps about 3 significant decimal digits.
+func NewFloat16Codec() Codec {
+	return float16Codec{}
+}
+
+// NewInt8Codec returns a [Codec] storing 1 byte per dimension, scalar quantized against the largest absolute value
+// of each vector, whose scale is stored along with it. The absolute error is at most scale/2 per dimension.
+func NewInt8Codec() Codec {
+	return int8Codec{}
+}
+
+type float64Codec struct{}
+
+func (float64Codec) Encode(value []float64) ([]byte, error) {
+	data := make([]byte, 1, 1+8*len(value))
+	data[0] = formatFloat64
+	for _, v := range value {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(v))
+	}
+	return data, nil
+}
+
+func (float64Codec) Decode(data []byte) ([]float64, error) {
+	body, err := checkFormat(data, formatFloat64, 0, 8)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]float64, len(body)/8)
+	for i := range value {
+		value[i] = math.Float64frombits(binary.LittleEndian.Uint64(body[8*i:]))
+	}
+	return value, nil
+}
+
+type float32Codec struct{}
+
+func (float32Codec) Encode(value []float64) ([]byte, error) {
+	data := make([]byte, 1, 1+4*len(value))
+	data[0] = formatFloat32
+	for _, v := range value {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(v)))
+	}
+	return data, nil
+}
+
+func (float32Codec) Decode(data []byte) ([]float64, error) {
+	body, err := checkFormat(data, formatFloat32, 0, 4)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]float64, len(body)/4)
+	for i := range value {
+		value[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(body[4*i:])))
+	}
+	return value, nil
+}
+
+type float16Codec struct{}
+
+func (float16Codec) Encode(value []float64) ([]byte, error) {
+	data := make([]byte, 1, 1+2*len(value))
+	data[0] = formatFloat16
+	for _, v := range value {
+		data = binary.LittleEndian.AppendUint16(data, float32ToFloat16(float32(v)))
+	}
+	return data, nil
+}
+
+func (float16Codec) Decode(data []byte) ([]float64, error) {
+	body, err := checkFormat(data, formatFloat16, 0, 2)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]float64, len(body)/2)
+	for i := range value {
+		value[i] = float64(float16ToFloat32(binary.LittleEndian.Uint16(body[2*i:])))
+	}
+	return value, nil
+}
+
+type int8Codec struct{}
+
+func (int8Codec) Encode(value []float64) ([]byte, error) {
+	var maxAbs float64
+	for _, v := range value {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("embedding/cache: int8 codec cannot encode %v", v)
+		}
+		maxAbs = math.Max(maxAbs, math.Abs(v))
+	}
+	scale := float32(maxAbs / 127)
+
+	data := make([]byte, 1, 5+len(value))
+	data[0] = formatInt8
+	data = binary.LittleEndian.AppendUint32(data, math.Float32bits(scale))
+	for _, v := range value {
+		var q float64
+		if scale > 0 {
+			q = math.Max(-127, math.Min(127, math.Round(v/float64(scale))))
+		}
+		data = append(data, byte(int8(q)))
+	}
+	return data, nil
+}
+
+func (int8Codec) Decode(data []byte) ([]float64, error) {
+	body, err := checkFormat(data, formatInt8, 4, 1)
+	if err != nil {
+		return nil, err
+	}
+	scale := float64(math.Float32frombits(binary.LittleEndian.Uint32(body)))
+	body = body[4:]
+	value := make([]float64, len(body))
+	for i, b := range body {
+		value[i] = float64(int8(b)) * scale
+	}
+	return value, nil
+}
+
+// checkFormat validates the format tag and returns the rest of data,
+// which must hold a header of headerSize bytes followed by elements of elemSize bytes.
+func checkFormat(data []byte, format byte, headerSize, elemSize int) ([]byte, error) {
+	if len(data) < 1+headerSize || data[0] != format || (len(data)-1-headerSize)%elemSize != 0 {
+		return nil, ErrInvalidEncoding
+	}
+	return data[1:], nil
+}
+
+// float32ToFloat16 converts to IEEE 754 half precision, rounding to nearest even.
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits&0x7fffffff == 0:
+		return sign
+	case exp >= 0x1f:
+		if bits&0x7f800000 == 0x7f800000 && mant != 0 {
+			return sign | 0x7e00 // NaN
+		}
+		return sign | 0x7c00 // overflow to infinity
+	case exp <= 0:
+		// subnormal half
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := uint16(mant >> shift)
+		rem, mid := mant&(1<<shift-1), uint32(1)<<(shift-1)
+		if rem > mid || (rem == mid && half&1 == 1) {
+			half++
+		}
+		return sign | half
+	default:
+		half := sign | uint16(exp)<<10 | uint16(mant>>13)
+		// a carry out of the mantissa correctly bumps the exponent
+		rem := mant & 0x1fff
+		if rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+			half++
+		}
+		return half
+	}
+}
+
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// subnormal half, normalize the mantissa
+		e := uint32(127 - 15 + 1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		return math.Float32frombits(sign | e<<23 | (mant&0x3ff)<<13)
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp-15+127)<<23 | mant<<13)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec(t *testing.T) {
+	value := []float64{0.12345678, -0.5, 0, 1, -0.0001, 0.9999}
+
+	for _, tt := range []struct {
+		name      string
+		codec     Codec
+		size      int
+		tolerance float64
+	}{
+		{"float64", NewFloat64Codec(), 1 + 8*len(value), 0},
+		{"float32", NewFloat32Codec(), 1 + 4*len(value), 1e-7},
+		{"float16", NewFloat16Codec(), 1 + 2*len(value), 1e-3},
+		{"int8", NewInt8Codec(), 5 + len(value), 1.0 / 254},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.codec.Encode(value)
+			require.NoError(t, err)
+			assert.Len(t, data, tt.size)
+
+			out, err := tt.codec.Decode(data)
+			require.NoError(t, err)
+			require.Len(t, out, len(value))
+			for i := range value {
+				assert.InDelta(t, value[i], out[i], tt.tolerance, "dimension %d", i)
+			}
+
+			data, err = tt.codec.Encode(nil)
+			require.NoError(t, err)
+			out, err = tt.codec.Decode(data)
+			require.NoError(t, err)
+			assert.Empty(t, out)
+
+			// data of other codecs and truncated data are rejected
+			_, err = tt.codec.Decode([]byte(`[1.0,2.0]`))
+			assert.ErrorIs(t, err, ErrInvalidEncoding)
+			_, err = tt.codec.Decode(nil)
+			assert.ErrorIs(t, err, ErrInvalidEncoding)
+		})
+	}
+
+	data, err := NewFloat32Codec().Encode(value)
+	require.NoError(t, err)
+	_, err = NewFloat16Codec().Decode(data)
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, err = NewFloat64Codec().Decode(data[:len(data)-1])
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+
+	_, err = NewInt8Codec().Encode([]float64{math.NaN()})
+	assert.Error(t, err)
+}
+
+func TestCodec_Int8Extremes(t *testing.T) {
+	c := NewInt8Codec()
+
+	data, err := c.Encode([]float64{0, 0})
+	require.NoError(t, err)
+	out, err := c.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 0}, out)
+
+	data, err = c.Encode([]float64{-254, 127, 1})
+	require.NoError(t, err)
+	out, err = c.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-254, 128, 2}, out)
+}
+
+func TestFloat16(t *testing.T) {
+	for _, tt := range []struct {
+		in   float32
+		bits uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{65504, 0x7bff},
+		{1e6, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{5.9604645e-8, 0x0001}, // smallest subnormal
+		{6.1035156e-5, 0x0400}, // smallest normal
+		{1 + 1.0/2048, 0x3c00}, // tie, rounds to even
+		{1 + 3.0/2048, 0x3c02}, // tie, rounds to even
+	} {
+		assert.Equal(t, tt.bits, float32ToFloat16(tt.in), "%v", tt.in)
+		if !math.IsInf(float64(tt.in), 0) && tt.in < 65520 {
+			assert.Equal(t, float32ToFloat16(tt.in), float32ToFloat16(float16ToFloat32(tt.bits)))
+		}
+	}
+
+	assert.Equal(t, float32(65504), float16ToFloat32(0x7bff))
+	assert.Equal(t, float32(5.9604645e-8), float16ToFloat32(0x0001))
+	assert.True(t, math.IsNaN(float64(float16ToFloat32(float32ToFloat16(float32(math.NaN()))))))
+}
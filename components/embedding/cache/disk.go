@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DiskCacher is a [Cacher] persisting embeddings as files under a local directory,
+// for batch jobs that repeatedly embed the same corpus without a shared cache server.
+//
+// Every key is stored in its own file named after the SHA-256 of the key, so keys of any length
+// or character set are accepted. Files are replaced atomically, making the cacher safe for concurrent use,
+// also across processes sharing the directory. Expired entries are removed when read.
+type DiskCacher struct {
+	dir   string
+	codec Codec
+	now   func() time.Time
+}
+
+var _ Cacher = (*DiskCacher)(nil)
+
+// NewDiskCacher creates a [DiskCacher] storing files under dir, which is created if it does not exist.
+// A nil codec defaults to the lossless [NewFloat64Codec].
+func NewDiskCacher(dir string, codec Codec) (*DiskCacher, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir failed: %w", err)
+	}
+	if codec == nil {
+		codec = NewFloat64Codec()
+	}
+	return &DiskCacher{
+		dir:   dir,
+		codec: codec,
+		now:   time.Now,
+	}, nil
+}
+
+// Set stores the value, an expire of zero or less means the entry never expires.
+func (c *DiskCacher) Set(_ context.Context, key string, value []float64, expire time.Duration) error {
+	encoded, err := c.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	var expireAt int64
+	if expire > 0 {
+		expireAt = c.now().Add(expire).UnixNano()
+	}
+	data := binary.LittleEndian.AppendUint64(make([]byte, 0, 8+len(encoded)), uint64(expireAt))
+	data = append(data, encoded...)
+
+	path := c.path(key)
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create cache dir failed: %w", err)
+	}
+
+	// write to a temporary file first, so readers never see a partial entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create cache file failed: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write cache file failed: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write cache file failed: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename cache file failed: %w", err)
+	}
+	return nil
+}
+
+func (c *DiskCacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("read cache file failed: %w", err)
+	}
+	if len(data) < 8 {
+		// a truncated file is a miss, overwritten by the next Set
+		return nil, false, nil
+	}
+
+	if expireAt := int64(binary.LittleEndian.Uint64(data)); expireAt != 0 && c.now().UnixNano() >= expireAt {
+		_ = os.Remove(path)
+		return nil, false, nil
+	}
+
+	value, err := c.codec.Decode(data[8:])
+	if err != nil {
+		if errors.Is(err, ErrInvalidEncoding) {
+			// written by another codec, a miss overwritten by the next Set
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// path spreads the files over 256 sub directories to keep directories small.
+func (c *DiskCacher) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCacher(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	c, err := NewDiskCacher(dir, NewFloat32Codec())
+	require.NoError(t, err)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	key := "text with / and : in it"
+	require.NoError(t, c.Set(ctx, key, []float64{0.5, -1}, time.Minute))
+	require.NoError(t, c.Set(ctx, "forever", []float64{1}, 0))
+
+	value, ok, err := c.Get(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0.5, -1}, value)
+
+	_, ok, err = c.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// entries survive a new cacher on the same directory
+	reopened, err := NewDiskCacher(dir, NewFloat32Codec())
+	require.NoError(t, err)
+	value, ok, err = reopened.Get(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0.5, -1}, value)
+
+	// overwrite
+	require.NoError(t, c.Set(ctx, key, []float64{2}, time.Minute))
+	value, _, err = c.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2}, value)
+
+	// expiration removes the file
+	now = now.Add(time.Hour)
+	_, ok, err = c.Get(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, err = os.Stat(c.path(key))
+	assert.True(t, os.IsNotExist(err))
+
+	_, ok, err = c.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// no temporary file is left behind
+	entries, err := filepath.Glob(filepath.Join(dir, "*", ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// data written by a different codec is a miss, overwritten by the next Set
+	other, err := NewDiskCacher(dir, nil)
+	require.NoError(t, err)
+	other.now = c.now
+	_, ok, err = other.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, other.Set(ctx, "forever", []float64{0.1}, 0))
+	value, ok, err = other.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0.1}, value)
+
+	// so is a truncated file
+	require.NoError(t, os.WriteFile(c.path("forever"), []byte("bad"), 0o644))
+	_, ok, err = c.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
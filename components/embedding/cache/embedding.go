@@ -19,14 +19,30 @@ package cache
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
 )
 
+const (
+	// CacheHits is the callback extra key of the number of texts whose embedding was found in the cache.
+	CacheHits = "cache_hits"
+	// CacheMisses is the callback extra key of the number of texts embedded by the underlying embedder.
+	CacheMisses = "cache_misses"
+	// CacheShared is the callback extra key of the number of texts whose embedding was awaited from a concurrent call.
+	CacheShared = "cache_shared"
+)
+
 var (
 	ErrCacherRequired    = errors.New("embedding/cache: cacher is required")
 	ErrGeneratorRequired = errors.New("embedding/cache: generator is required")
+
+	errEmbeddingAborted = errors.New("embedding/cache: embedding aborted")
 )
 
 type Embedder struct {
@@ -34,6 +50,9 @@ type Embedder struct {
 	cacher     Cacher
 	generator  Generator
 	expiration time.Duration
+
+	inflightMu sync.Mutex
+	inflight   map[string]*inflightCall
 }
 
 type Option interface {
@@ -74,6 +93,7 @@ func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error)
 	e := &Embedder{
 		embedder:   embedder,
 		expiration: time.Hour * 2,
+		inflight:   make(map[string]*inflightCall),
 	}
 	for _, opt := range opts {
 		opt.apply(e)
@@ -90,13 +110,21 @@ func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error)
 	return e, nil
 }
 
-func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
-	var (
-		embeddingsByKey = make(map[int][]float64)
-		embeddingOpts   = embedding.GetCommonOptions(nil, opts...)
-		uncached        []int
-		uncachedTexts   []string
-	)
+// EmbedStrings returns the cached embeddings of texts and embeds the rest with the underlying embedder.
+// Identical texts are embedded once per call, and texts already being embedded by a concurrent call
+// wait for that result instead of being sent to the provider again.
+//
+// The callback output reports how many texts were served from the cache in Extra,
+// under [CacheHits], [CacheMisses] and [CacheShared].
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	embeddingOpts := embedding.GetCommonOptions(nil, opts...)
 
 	// generate options for the generator
 	var generatorOpt GeneratorOption
@@ -104,47 +132,211 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		generatorOpt.Model = *embeddingOpts.Model
 	}
 
-	// Get cached embeddings and find uncached texts
+	conf := &embedding.Config{Model: generatorOpt.Model}
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+	})
+
+	embeddings, stats, err := e.embedStrings(ctx, texts, generatorOpt, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		Extra: map[string]any{
+			CacheHits:   stats.hits,
+			CacheMisses: stats.misses,
+			CacheShared: stats.shared,
+		},
+	})
+
+	return embeddings, nil
+}
+
+// cacheStats counts the texts of one call by where their embedding came from.
+type cacheStats struct {
+	hits, misses, shared int
+}
+
+func (e *Embedder) embedStrings(ctx context.Context, texts []string, generatorOpt GeneratorOption,
+	opts ...embedding.Option) ([][]float64, cacheStats, error) {
+
+	var stats cacheStats
+
+	// Group identical texts by key, so each key is looked up and embedded once
+	var (
+		keys      []string
+		keyTexts  []string
+		positions = make(map[string][]int, len(texts))
+	)
 	for idx, text := range texts {
 		key := e.generator.Generate(ctx, text, generatorOpt)
-		emb, ok, err := e.cacher.Get(ctx, key)
+		if _, ok := positions[key]; !ok {
+			keys = append(keys, key)
+			keyTexts = append(keyTexts, text)
+		}
+		positions[key] = append(positions[key], idx)
+	}
+
+	result := make([][]float64, len(texts))
+	// fill gives every position of key its own copy of emb, as emb may be shared with
+	// the cacher, the waiters of an inflight call and the duplicates of the text.
+	fill := func(key string, emb []float64) int {
+		for _, idx := range positions[key] {
+			result[idx] = slices.Clone(emb)
+		}
+		return len(positions[key])
+	}
+
+	if len(keys) == 0 {
+		return result, stats, nil
+	}
+
+	// Get cached embeddings and find uncached texts
+	cached, found, err := batchGet(ctx, e.cacher, keys)
+	if err != nil {
+		return nil, stats, err
+	}
+
+	var (
+		ownKeys, ownTexts []string
+		ownCalls          []*inflightCall
+		waitKeys          []string
+		waitTexts         []string
+		waitCalls         []*inflightCall
+	)
+	e.inflightMu.Lock()
+	for i, key := range keys {
+		if found[i] {
+			stats.hits += fill(key, cached[i])
+			continue
+		}
+		// If another call is already embedding this key, wait for it instead
+		if call, ok := e.inflight[key]; ok {
+			waitKeys = append(waitKeys, key)
+			waitTexts = append(waitTexts, keyTexts[i])
+			waitCalls = append(waitCalls, call)
+			continue
+		}
+		call := &inflightCall{done: make(chan struct{})}
+		e.inflight[key] = call
+		ownKeys = append(ownKeys, key)
+		ownTexts = append(ownTexts, keyTexts[i])
+		ownCalls = append(ownCalls, call)
+	}
+	e.inflightMu.Unlock()
+
+	// Embed the texts this call is responsible for, before waiting on others, so concurrent calls never wait on each other
+	if len(ownKeys) > 0 {
+		embeddings, err := e.embedAndCache(ctx, ownKeys, ownTexts, ownCalls, opts...)
 		if err != nil {
-			return nil, err
-		} else if ok {
-			embeddingsByKey[idx] = emb
-		} else {
-			// If the key is not found, we consider it as uncached
-			uncached = append(uncached, idx)
-			uncachedTexts = append(uncachedTexts, text)
+			return nil, stats, err
+		}
+		for i, key := range ownKeys {
+			stats.misses += fill(key, embeddings[i])
 		}
 	}
 
-	// Embed the uncached texts
-	if len(uncachedTexts) > 0 {
-		uncachedEmbeddings, err := e.embedder.EmbedStrings(ctx, uncachedTexts, opts...)
+	// Collect the results of concurrent calls, embedding again what they failed to embed
+	var retryKeys, retryTexts []string
+	for i, call := range waitCalls {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, stats, ctx.Err()
+		}
+		if call.err != nil {
+			retryKeys = append(retryKeys, waitKeys[i])
+			retryTexts = append(retryTexts, waitTexts[i])
+			continue
+		}
+		stats.shared += fill(waitKeys[i], call.emb)
+	}
+	if len(retryKeys) > 0 {
+		embeddings, err := e.embedAndCache(ctx, retryKeys, retryTexts, nil, opts...)
 		if err != nil {
-			return nil, err
+			return nil, stats, err
+		}
+		for i, key := range retryKeys {
+			stats.misses += fill(key, embeddings[i])
 		}
+	}
+
+	return result, stats, nil
+}
 
-		// Cache the uncachedEmbeddings
-		for i, idx := range uncached {
-			key := e.generator.Generate(ctx, texts[idx], generatorOpt)
-			if err := e.cacher.Set(ctx, key, uncachedEmbeddings[i], e.expiration); err != nil {
-				_ = err // skip caching if there's an error
+// inflightCall is an embedding of one key in progress, done is closed once emb or err is set.
+type inflightCall struct {
+	done chan struct{}
+	emb  []float64
+	err  error
+}
+
+// embedAndCache embeds texts, caches the result under keys and, if calls is not empty,
+// publishes the result to the waiters of calls and releases them.
+func (e *Embedder) embedAndCache(ctx context.Context, keys, texts []string, calls []*inflightCall,
+	opts ...embedding.Option) (embeddings [][]float64, err error) {
+
+	if len(calls) > 0 {
+		defer func() {
+			callErr := err
+			if callErr == nil && len(embeddings) != len(calls) {
+				// the embedder panicked, let the waiters embed on their own
+				callErr = errEmbeddingAborted
 			}
-			embeddingsByKey[idx] = uncachedEmbeddings[i]
-		}
+			e.inflightMu.Lock()
+			for i, call := range calls {
+				if callErr != nil {
+					call.err = callErr
+				} else {
+					call.emb = embeddings[i]
+				}
+				delete(e.inflight, keys[i])
+				close(call.done)
+			}
+			e.inflightMu.Unlock()
+		}()
 	}
 
-	// Convert the map to a slice
-	result := make([][]float64, len(texts))
-	for i := range texts {
-		if emb, ok := embeddingsByKey[i]; ok {
-			result[i] = emb
-		} else {
-			result[i] = nil // it seems that such a case should not happen
-		}
+	embeddings, err = e.embedder.EmbedStrings(e.makeEmbeddingCtx(ctx), texts, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("embedding/cache: embedder returned %d embeddings for %d texts", len(embeddings), len(texts))
+	}
+
+	// Cache the embeddings, skip caching if there's an error
+	_ = batchSet(ctx, e.cacher, keys, embeddings, e.expiration)
+
+	return embeddings, nil
+}
+
+// makeEmbeddingCtx reports the callbacks of the underlying embedder under its own run info.
+func (e *Embedder) makeEmbeddingCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
 	}
 
-	return result, nil
+	if embType, ok := components.GetType(e.embedder); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "Cache"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
 }
@@ -19,9 +19,12 @@ package cache
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -198,4 +201,170 @@ func TestEmbedder_EmbedStrings(t *testing.T) {
 		mc.AssertExpectations(t)
 		me.AssertExpectations(t)
 	})
+
+	t.Run("batch cacher and duplicate texts", func(t *testing.T) {
+		me := new(mockEmbedder)
+		lru := NewLRUCacher(10)
+		e, err := NewEmbedder(me, WithCacher(lru), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+		require.NoError(t, err)
+
+		key1 := e.generator.Generate(ctx, texts[1], generatorOpt)
+		require.NoError(t, lru.Set(ctx, key1, embeddings[1], expiration))
+		me.On("EmbedStrings", mock.Anything, []string{texts[0]}, mock.Anything).Return([][]float64{embeddings[0]}, nil).Once()
+
+		result, err := e.EmbedStrings(ctx, []string{texts[0], texts[1], texts[0]})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{embeddings[0], embeddings[1], embeddings[0]}, result)
+		assert.Equal(t, 2, lru.Len())
+		assert.Empty(t, e.inflight)
+		me.AssertExpectations(t)
+
+		// every result owns its backing array, shared with neither the duplicates nor the cache
+		result[0][0] = 0
+		assert.Equal(t, embeddings[0], result[2])
+		value, ok, err := lru.Get(ctx, e.generator.Generate(ctx, texts[0], generatorOpt))
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, embeddings[0], value)
+	})
+}
+
+type blockingEmbedder struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	err     error
+}
+
+func (b *blockingEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if b.calls.Add(1) == 1 {
+		close(b.started)
+		<-b.release
+		if b.err != nil {
+			return nil, b.err
+		}
+	}
+	result := make([][]float64, len(texts))
+	for i, text := range texts {
+		result[i] = []float64{float64(len(text))}
+	}
+	return result, nil
+}
+
+func TestEmbedder_InflightDedup(t *testing.T) {
+	ctx := context.Background()
+
+	run := func(t *testing.T, embedErr error) (*blockingEmbedder, [][]float64, error) {
+		be := &blockingEmbedder{started: make(chan struct{}), release: make(chan struct{}), err: embedErr}
+		e, err := NewEmbedder(be, WithCacher(NewLRUCacher(10)), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		var (
+			wg       sync.WaitGroup
+			firstErr error
+			second   [][]float64
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, firstErr = e.EmbedStrings(ctx, []string{"foo"})
+		}()
+		<-be.started
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			second, err = e.EmbedStrings(ctx, []string{"foo"})
+			assert.NoError(t, err)
+		}()
+		// give the second call time to find the in-flight embedding
+		time.Sleep(20 * time.Millisecond)
+		close(be.release)
+		wg.Wait()
+		assert.Empty(t, e.inflight)
+		return be, second, firstErr
+	}
+
+	t.Run("share result", func(t *testing.T) {
+		be, second, err := run(t, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{3}}, second)
+		assert.Equal(t, int32(1), be.calls.Load())
+	})
+
+	t.Run("owner failed", func(t *testing.T) {
+		be, second, err := run(t, errors.New("embed error"))
+		assert.Error(t, err)
+		assert.Equal(t, [][]float64{{3}}, second)
+		assert.Equal(t, int32(2), be.calls.Load())
+	})
+
+	t.Run("waiter canceled", func(t *testing.T) {
+		be := &blockingEmbedder{started: make(chan struct{}), release: make(chan struct{})}
+		e, err := NewEmbedder(be, WithCacher(NewLRUCacher(10)), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = e.EmbedStrings(ctx, []string{"foo"})
+		}()
+		<-be.started
+
+		cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err = e.EmbedStrings(cctx, []string{"foo"})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(be.release)
+		<-done
+	})
+}
+
+func TestEmbedder_Callbacks(t *testing.T) {
+	me := new(mockEmbedder)
+	lru := NewLRUCacher(10)
+	e, err := NewEmbedder(me, WithCacher(lru), WithGenerator(NewContentHashGenerator(nil)))
+	require.NoError(t, err)
+
+	var (
+		input  *embedding.CallbackInput
+		output *embedding.CallbackOutput
+		runErr error
+	)
+	handler := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+			input = embedding.ConvCallbackInput(in)
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+			output = embedding.ConvCallbackOutput(out)
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			runErr = err
+			return ctx
+		}).
+		Build()
+	ctx := callbacks.InitCallbacks(context.Background(), &callbacks.RunInfo{}, handler)
+
+	require.NoError(t, lru.Set(ctx, e.generator.Generate(ctx, "foo", GeneratorOption{Model: "m"}), []float64{1}, 0))
+	me.On("EmbedStrings", mock.Anything, []string{"bar"}, mock.Anything).Return([][]float64{{2}}, nil).Once()
+
+	result, err := e.EmbedStrings(ctx, []string{"foo", "bar", "bar"}, embedding.WithModel("m"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{1}, {2}, {2}}, result)
+	require.NotNil(t, input)
+	assert.Equal(t, []string{"foo", "bar", "bar"}, input.Texts)
+	assert.Equal(t, "m", input.Config.Model)
+	require.NotNil(t, output)
+	assert.Equal(t, result, output.Embeddings)
+	assert.Equal(t, map[string]any{CacheHits: 1, CacheMisses: 2, CacheShared: 0}, output.Extra)
+
+	me.On("EmbedStrings", mock.Anything, []string{"baz"}, mock.Anything).Return(nil, errors.New("embed error")).Once()
+	_, err = e.EmbedStrings(ctx, []string{"baz"})
+	assert.Error(t, err)
+	assert.Equal(t, err, runErr)
+	me.AssertExpectations(t)
 }
@@ -18,7 +18,6 @@ package main
 
 import (
 	"context"
-	"crypto/md5"
 	"log"
 
 	"github.com/cloudwego/eino-ext/components/embedding/cache"
@@ -40,8 +39,11 @@ func main() {
 	// ...
 
 	embedder, err := cache.NewEmbedder(originalEmbedder,
-		cache.WithCacher(cacheredis.NewCacher(rdb)),            // using Redis as the cache
-		cache.WithGenerator(cache.NewHashGenerator(md5.New())), // using md5 for generating unique keys
+		cache.WithCacher(cacheredis.NewCacher(rdb)), // using Redis as the cache
+		cache.WithGenerator(cache.NewContentHashGenerator(&cache.ContentHashGeneratorConfig{
+			Namespace: "emb:v1", // bump it after changing the model to drop stale embeddings
+			Model:     "text-embedding-3-small",
+		})),
 	)
 	if err != nil {
 		log.Fatal(err)
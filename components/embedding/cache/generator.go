@@ -18,8 +18,13 @@ package cache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"hash"
+	"strconv"
+	"sync"
 )
 
 // GeneratorOption holds options for generating unique keys.
@@ -51,6 +56,8 @@ func (g *SimpleGenerator) Generate(_ context.Context, text string, opt Generator
 // HashGenerator is a concrete implementation of the [Generator] interface that uses a hash function
 // to generate a unique key based on the provided text and optional embedding options.
 // It wraps a [SimpleGenerator] and applies a hash function to the generated key.
+// The hasher is shared, so calls are serialized, see [ContentHashGenerator] for a generator without contention
+// that also covers dimensions and a namespace.
 //
 // Note: Because of the use of the [hash.Hash] algorithm, there is a probability that data
 // with different text and options will generate the same key. This is a trade-off
@@ -58,6 +65,8 @@ func (g *SimpleGenerator) Generate(_ context.Context, text string, opt Generator
 // using a different generator or a more complex hashing strategy.
 type HashGenerator struct {
 	*SimpleGenerator
+
+	mu     sync.Mutex
 	hasher hash.Hash
 }
 
@@ -73,5 +82,77 @@ func NewHashGenerator(hasher hash.Hash) *HashGenerator {
 
 func (g *HashGenerator) Generate(ctx context.Context, text string, opt GeneratorOption) string {
 	plainText := g.SimpleGenerator.Generate(ctx, text, opt)
-	return fmt.Sprintf("%x", g.hasher.Sum([]byte(plainText)))
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hasher.Reset()
+	_, _ = g.hasher.Write([]byte(plainText))
+	return hex.EncodeToString(g.hasher.Sum(nil))
+}
+
+// ContentHashGeneratorConfig configures a [ContentHashGenerator].
+type ContentHashGeneratorConfig struct {
+	// Namespace is prepended to every key, e.g. "emb:v2". Changing it, for instance after upgrading
+	// the embedding model or its preprocessing, makes all previously cached embeddings unreachable.
+	// Optional.
+	Namespace string
+	// Model is the model name used when a call does not set one with embedding.WithModel,
+	// usually the default model of the wrapped embedder.
+	// Optional.
+	Model string
+	// Dimensions is the size of the embeddings, so the same model truncated to different sizes gets different keys.
+	// Optional.
+	Dimensions int
+	// NewHash creates the hash function, a new one is used for every key.
+	// Optional. Default: sha256.New.
+	NewHash func() hash.Hash
+}
+
+// ContentHashGenerator is a [Generator] that hashes the namespace, model, dimensions and text into a key
+// of bounded length: the namespace followed by the hex encoded digest.
+// It is safe for concurrent use.
+type ContentHashGenerator struct {
+	namespace  string
+	model      string
+	dimensions int
+	newHash    func() hash.Hash
+}
+
+var _ Generator = (*ContentHashGenerator)(nil)
+
+// NewContentHashGenerator creates a new [ContentHashGenerator], config may be nil.
+func NewContentHashGenerator(config *ContentHashGeneratorConfig) *ContentHashGenerator {
+	if config == nil {
+		config = &ContentHashGeneratorConfig{}
+	}
+	g := &ContentHashGenerator{
+		namespace:  config.Namespace,
+		model:      config.Model,
+		dimensions: config.Dimensions,
+		newHash:    config.NewHash,
+	}
+	if g.newHash == nil {
+		g.newHash = sha256.New
+	}
+	return g
+}
+
+func (g *ContentHashGenerator) Generate(_ context.Context, text string, opt GeneratorOption) string {
+	model := opt.Model
+	if model == "" {
+		model = g.model
+	}
+
+	h := g.newHash()
+	// length prefixed fields, so no two different inputs are written as the same bytes
+	for _, field := range []string{g.namespace, model, strconv.Itoa(g.dimensions), text} {
+		_, _ = h.Write(binary.AppendUvarint(nil, uint64(len(field))))
+		_, _ = h.Write([]byte(field))
+	}
+
+	digest := hex.EncodeToString(h.Sum(nil))
+	if g.namespace == "" {
+		return digest
+	}
+	return g.namespace + ":" + digest
 }
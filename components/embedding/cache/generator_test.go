@@ -20,7 +20,11 @@ import (
 	"context"
 	"crypto/md5"
 	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"hash"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,6 +40,7 @@ func TestGenerator_UniquenessAndDifference(t *testing.T) {
 	}{
 		{"SimpleGenerator", NewSimpleGenerator()},
 		{"HashGenerator", NewHashGenerator(sha256.New())},
+		{"ContentHashGenerator", NewContentHashGenerator(nil)},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Run("Generate uniqueness", func(t *testing.T) {
@@ -85,7 +90,67 @@ func TestGenerator_HashGenerator(t *testing.T) {
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			generator := NewHashGenerator(tt.hash)
-			assert.NotEmpty(t, generator.Generate(ctx, text, GeneratorOption{Model: model}))
+			key := generator.Generate(ctx, text, GeneratorOption{Model: model})
+			assert.Equal(t, 2*tt.hash.Size(), len(key))
+			assert.Equal(t, key, generator.Generate(ctx, text, GeneratorOption{Model: model}))
 		})
 	}
+
+	sum := sha256.Sum256([]byte(text + "-" + model))
+	assert.Equal(t, hex.EncodeToString(sum[:]), NewHashGenerator(sha256.New()).Generate(ctx, text, GeneratorOption{Model: model}))
+}
+
+func TestGenerator_ContentHashGenerator(t *testing.T) {
+	ctx := context.Background()
+	long := strings.Repeat("long text ", 1000)
+
+	generator := NewContentHashGenerator(&ContentHashGeneratorConfig{
+		Namespace:  "emb:v1",
+		Model:      "model-a",
+		Dimensions: 1024,
+	})
+	key := generator.Generate(ctx, long, GeneratorOption{})
+	assert.True(t, strings.HasPrefix(key, "emb:v1:"))
+	assert.Len(t, key, len("emb:v1:")+2*sha256.Size)
+
+	// the default model is used when the call does not set one
+	assert.Equal(t, key, generator.Generate(ctx, long, GeneratorOption{Model: "model-a"}))
+	assert.NotEqual(t, key, generator.Generate(ctx, long, GeneratorOption{Model: "model-b"}))
+
+	for _, config := range []*ContentHashGeneratorConfig{
+		{Namespace: "emb:v2", Model: "model-a", Dimensions: 1024},
+		{Namespace: "emb:v1", Model: "model-a", Dimensions: 512},
+		{Namespace: "emb:v1", Model: "model-a", Dimensions: 1024, NewHash: md5.New},
+	} {
+		assert.NotEqual(t, key, NewContentHashGenerator(config).Generate(ctx, long, GeneratorOption{}))
+	}
+
+	// fields are length prefixed, so moving bytes between model and text changes the key
+	g := NewContentHashGenerator(nil)
+	assert.NotEqual(t, g.Generate(ctx, "bc", GeneratorOption{Model: "a"}), g.Generate(ctx, "c", GeneratorOption{Model: "ab"}))
+	assert.Len(t, g.Generate(ctx, "", GeneratorOption{}), 2*sha256.Size)
+}
+
+func TestGenerator_Concurrent(t *testing.T) {
+	ctx := context.Background()
+
+	for _, generator := range []Generator{
+		NewHashGenerator(sha256.New()),
+		NewContentHashGenerator(nil),
+	} {
+		want := make([]string, 50)
+		for i := range want {
+			want[i] = generator.Generate(ctx, fmt.Sprintf("text %d", i), GeneratorOption{})
+		}
+
+		var wg sync.WaitGroup
+		for i := range want {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				assert.Equal(t, want[i], generator.Generate(ctx, fmt.Sprintf("text %d", i), GeneratorOption{}))
+			}(i)
+		}
+		wg.Wait()
+	}
 }
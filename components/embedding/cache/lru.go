@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultLRUMaxEntries = 10000
+
+// LRUCacher is an in-process [Cacher] that keeps at most maxEntries embeddings,
+// evicting the least recently used one when full. Expired entries are dropped lazily on access.
+//
+// Values are copied when stored and when returned, so callers may modify them freely.
+type LRUCacher struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type lruEntry struct {
+	key      string
+	value    []float64
+	expireAt time.Time
+}
+
+var _ BatchCacher = (*LRUCacher)(nil)
+
+// NewLRUCacher creates an [LRUCacher] holding up to maxEntries embeddings,
+// a non-positive maxEntries defaults to 10000.
+func NewLRUCacher(maxEntries int) *LRUCacher {
+	if maxEntries <= 0 {
+		maxEntries = defaultLRUMaxEntries
+	}
+	return &LRUCacher{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Len returns the number of entries in the cache, including expired ones not yet evicted.
+func (c *LRUCacher) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Set stores the value, an expire of zero or less means the entry only leaves the cache by eviction.
+func (c *LRUCacher) Set(_ context.Context, key string, value []float64, expire time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, expire)
+	return nil
+}
+
+func (c *LRUCacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.get(key)
+	return value, ok, nil
+}
+
+func (c *LRUCacher) BatchSet(_ context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, key := range keys {
+		c.set(key, values[i], expire)
+	}
+	return nil
+}
+
+func (c *LRUCacher) BatchGet(_ context.Context, keys []string) ([][]float64, []bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	for i, key := range keys {
+		values[i], found[i] = c.get(key)
+	}
+	return values, found, nil
+}
+
+func (c *LRUCacher) set(key string, value []float64, expire time.Duration) {
+	// the value is copied in and out so callers never share the cached backing array.
+	value = slices.Clone(value)
+	var expireAt time.Time
+	if expire > 0 {
+		expireAt = c.now().Add(expire)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expireAt = value, expireAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry{key: key, value: value, expireAt: expireAt})
+	for c.ll.Len() > c.maxEntries {
+		c.remove(c.ll.Back())
+	}
+}
+
+func (c *LRUCacher) get(key string) ([]float64, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expireAt.IsZero() && !c.now().Before(entry.expireAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return slices.Clone(entry.value), true
+}
+
+func (c *LRUCacher) remove(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*lruEntry).key)
+}
@@ -48,7 +48,15 @@ func WithPrefix(prefix string) Option {
 	})
 }
 
-var _ cache.Cacher = (*Cacher)(nil)
+// WithCodec sets the encoding of the stored embeddings, e.g. [cache.NewFloat32Codec] to halve memory usage
+// versus the default JSON encoding. Entries written with another encoding can't be read, so use a new prefix too.
+func WithCodec(codec cache.Codec) Option {
+	return optionFunc(func(c *Cacher) {
+		c.codec = &embeddingCodec{codec: codec}
+	})
+}
+
+var _ cache.BatchCacher = (*Cacher)(nil)
 
 func NewCacher(rdb redis.UniversalClient, opts ...Option) *Cacher {
 	cacher := &Cacher{
@@ -79,9 +87,105 @@ func (c *Cacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
 		return nil, false, err
 	}
 
+	return c.unmarshal(data)
+}
+
+// unmarshal decodes a stored value, data written by another codec is a miss overwritten by the next Set.
+func (c *Cacher) unmarshal(data []byte) ([]float64, bool, error) {
 	var value []float64
 	if err := c.codec.Unmarshal(data, &value); err != nil {
+		if errors.Is(err, cache.ErrInvalidEncoding) {
+			return nil, false, nil
+		}
 		return nil, false, err
 	}
 	return value, true, nil
 }
+
+// BatchSet stores all values with one pipelined round trip.
+func (c *Cacher) BatchSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	for i, key := range keys {
+		data, err := c.codec.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, c.prefix+key, data, expire)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// BatchGet retrieves all keys with a single MGET, or with pipelined GETs on a cluster
+// where the keys may live in different slots.
+func (c *Cacher) BatchGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return values, found, nil
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+
+	raw, err := c.batchGetRaw(ctx, prefixed)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, data := range raw {
+		if data == nil {
+			continue
+		}
+		if values[i], found[i], err = c.unmarshal(data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return values, found, nil
+}
+
+// batchGetRaw returns the raw value of each key, nil if the key does not exist.
+func (c *Cacher) batchGetRaw(ctx context.Context, keys []string) ([][]byte, error) {
+	raw := make([][]byte, len(keys))
+
+	if _, ok := c.rdb.(*redis.ClusterClient); ok {
+		pipe := c.rdb.Pipeline()
+		cmds := make([]*redis.StringCmd, len(keys))
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		for i, cmd := range cmds {
+			data, err := cmd.Bytes()
+			if err != nil {
+				if errors.Is(err, redis.Nil) {
+					continue
+				}
+				return nil, err
+			}
+			raw[i] = data
+		}
+		return raw, nil
+	}
+
+	results, err := c.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range results {
+		switch v := result.(type) {
+		case string:
+			raw[i] = []byte(v)
+		case []byte:
+			raw[i] = v
+		}
+	}
+	return raw, nil
+}
@@ -22,10 +22,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
 )
 
 type mockRedisClient struct {
@@ -159,6 +162,104 @@ func TestCacher(t *testing.T) {
 	})
 }
 
+func TestCacher_Batch(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	for _, tt := range []struct {
+		name string
+		rdb  redis.UniversalClient
+	}{
+		{"client", redis.NewClient(&redis.Options{Addr: mr.Addr()})},
+		{"cluster", redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.rdb.Close()
+			mr.FlushAll()
+			c := NewCacher(tt.rdb, WithPrefix("test"))
+
+			err := c.BatchSet(ctx, []string{"a", "b"}, [][]float64{{1.1, 2.2}, {3.3}}, time.Minute)
+			require.NoError(t, err)
+			assert.True(t, mr.Exists("test:a"))
+			assert.Equal(t, time.Minute, mr.TTL("test:b"))
+
+			values, found, err := c.BatchGet(ctx, []string{"b", "missing", "a"})
+			require.NoError(t, err)
+			assert.Equal(t, []bool{true, false, true}, found)
+			assert.Equal(t, [][]float64{{3.3}, nil, {1.1, 2.2}}, values)
+
+			values, found, err = c.BatchGet(ctx, nil)
+			require.NoError(t, err)
+			assert.Empty(t, values)
+			assert.Empty(t, found)
+			assert.NoError(t, c.BatchSet(ctx, nil, nil, time.Minute))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer rdb.Close()
+		mc := new(mockCodec)
+		c := NewCacher(rdb)
+		c.codec = mc
+
+		mc.On("Marshal", []float64{1}).Return(nil, errors.New("marshal error"))
+		mc.On("Unmarshal", mock.Anything, mock.Anything).Return(errors.New("unmarshal error"))
+		require.NoError(t, mr.Set("eino:a", "data"))
+
+		assert.EqualError(t, c.BatchSet(ctx, []string{"a"}, [][]float64{{1}}, time.Minute), "marshal error")
+		_, _, err := c.BatchGet(ctx, []string{"a"})
+		assert.EqualError(t, err, "unmarshal error")
+
+		mr.SetError("server down")
+		_, _, err = c.BatchGet(ctx, []string{"a"})
+		assert.Error(t, err)
+		mr.SetError("")
+	})
+}
+
+func TestCacher_WithCodec(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	c := NewCacher(rdb, WithCodec(cache.NewInt8Codec()))
+	require.NoError(t, c.Set(ctx, "a", []float64{1, -0.5, 0}, time.Minute))
+	require.NoError(t, c.BatchSet(ctx, []string{"b"}, [][]float64{{2}}, time.Minute))
+
+	data, err := mr.Get("eino:a")
+	require.NoError(t, err)
+	assert.Len(t, data, 8)
+
+	value, ok, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDeltaSlice(t, []float64{1, -0.5, 0}, value, 0.01)
+
+	values, found, err := c.BatchGet(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true}, found)
+	assert.InDeltaSlice(t, []float64{2}, values[1], 0.01)
+
+	// data written by a different codec is a miss, overwritten by the next Set
+	other := NewCacher(rdb, WithCodec(cache.NewFloat32Codec()))
+	_, ok, err = other.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, mr.Set("eino:c", ""))
+	values, found, err = other.BatchGet(ctx, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, false, false}, found)
+	assert.Equal(t, [][]float64{nil, nil, nil}, values)
+
+	require.NoError(t, other.Set(ctx, "a", []float64{0.5}, time.Minute))
+	value, ok, err = other.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0.5}, value)
+}
+
 func TestWithPrefix(t *testing.T) {
 	assert.Equal(t, "eino:", NewCacher(nil).prefix)
 	assert.Equal(t, "custom:", NewCacher(nil, WithPrefix("custom:")).prefix)
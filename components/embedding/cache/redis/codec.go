@@ -16,7 +16,13 @@
 
 package redis
 
-import "github.com/bytedance/sonic"
+import (
+	"fmt"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
+)
 
 var defaultCodec codec = &sonicCodec{}
 
@@ -34,3 +40,29 @@ func (*sonicCodec) Marshal(v any) ([]byte, error) {
 func (*sonicCodec) Unmarshal(data []byte, v any) error {
 	return sonic.Unmarshal(data, v)
 }
+
+// embeddingCodec adapts a [cache.Codec] to the codec interface of the cacher.
+type embeddingCodec struct {
+	codec cache.Codec
+}
+
+func (c *embeddingCodec) Marshal(v any) ([]byte, error) {
+	value, ok := v.([]float64)
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+	return c.codec.Encode(value)
+}
+
+func (c *embeddingCodec) Unmarshal(data []byte, v any) error {
+	ptr, ok := v.(*[]float64)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+	value, err := c.codec.Decode(data)
+	if err != nil {
+		return err
+	}
+	*ptr = value
+	return nil
+}
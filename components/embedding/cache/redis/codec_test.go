@@ -21,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
 )
 
 func TestCodec_Sonic(t *testing.T) {
@@ -42,3 +44,21 @@ func TestCodec_Sonic(t *testing.T) {
 func TestCodec_Default(t *testing.T) {
 	assert.Equal(t, &sonicCodec{}, defaultCodec)
 }
+
+func TestCodec_Embedding(t *testing.T) {
+	c := &embeddingCodec{codec: cache.NewFloat32Codec()}
+	v := []float64{0.5, -1}
+
+	data, err := c.Marshal(v)
+	require.NoError(t, err)
+	assert.Len(t, data, 9)
+
+	var out []float64
+	require.NoError(t, c.Unmarshal(data, &out))
+	assert.Equal(t, v, out)
+
+	_, err = c.Marshal("foo")
+	assert.Error(t, err)
+	assert.Error(t, c.Unmarshal(data, out))
+	assert.ErrorIs(t, c.Unmarshal([]byte("[1]"), &out), cache.ErrInvalidEncoding)
+}
@@ -5,6 +5,7 @@ go 1.23.0
 replace github.com/cloudwego/eino-ext/components/embedding/cache => ../
 
 require (
+	github.com/alicebob/miniredis/v2 v2.35.0
 	github.com/bytedance/sonic v1.14.1
 	github.com/cloudwego/eino-ext/components/embedding/cache v0.0.0-00010101000000-000000000000
 	github.com/redis/go-redis/v9 v9.8.0
@@ -39,6 +40,7 @@ require (
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	github.com/yargevad/filepathx v1.0.0 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	golang.org/x/arch v0.11.0 // indirect
 	golang.org/x/exp v0.0.0-20230713183714-613f0c0eb8a1 // indirect
 	golang.org/x/sys v0.33.0 // indirect
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TieredCacher chains several cachers, typically a small [LRUCacher] in front of a shared one such as Redis.
+// Reads go through the tiers in order and a hit is copied back into the tiers before it,
+// writes go to every tier.
+//
+// Backfilled entries are written without expiration since the remaining lifetime in the lower tier is unknown,
+// so upper tiers should be bounded by size, as [LRUCacher] is.
+type TieredCacher struct {
+	tiers []Cacher
+}
+
+var _ BatchCacher = (*TieredCacher)(nil)
+
+// NewTieredCacher creates a [TieredCacher] reading from the given cachers in order, fastest first.
+func NewTieredCacher(tiers ...Cacher) *TieredCacher {
+	return &TieredCacher{tiers: tiers}
+}
+
+// Set stores the value in every tier, a failing tier does not prevent writes to the others.
+func (c *TieredCacher) Set(ctx context.Context, key string, value []float64, expire time.Duration) error {
+	var errs []error
+	for _, tier := range c.tiers {
+		if err := tier.Set(ctx, key, value, expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *TieredCacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	for i, tier := range c.tiers {
+		value, ok, err := tier.Get(ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			for _, upper := range c.tiers[:i] {
+				_ = upper.Set(ctx, key, value, 0)
+			}
+			return value, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// BatchSet stores the values in every tier, a failing tier does not prevent writes to the others.
+func (c *TieredCacher) BatchSet(ctx context.Context, keys []string, values [][]float64, expire time.Duration) error {
+	var errs []error
+	for _, tier := range c.tiers {
+		if err := batchSet(ctx, tier, keys, values, expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BatchGet asks each tier only for the keys missed by the tiers before it.
+func (c *TieredCacher) BatchGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+
+	missing := make([]int, len(keys))
+	for i := range keys {
+		missing[i] = i
+	}
+
+	for t, tier := range c.tiers {
+		if len(missing) == 0 {
+			break
+		}
+
+		tierKeys := make([]string, len(missing))
+		for i, idx := range missing {
+			tierKeys[i] = keys[idx]
+		}
+		tierValues, tierFound, err := batchGet(ctx, tier, tierKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var (
+			hitKeys   []string
+			hitValues [][]float64
+			next      []int
+		)
+		for i, idx := range missing {
+			if !tierFound[i] {
+				next = append(next, idx)
+				continue
+			}
+			values[idx], found[idx] = tierValues[i], true
+			hitKeys = append(hitKeys, keys[idx])
+			hitValues = append(hitValues, tierValues[i])
+		}
+		if len(hitKeys) > 0 {
+			for _, upper := range c.tiers[:t] {
+				_ = batchSet(ctx, upper, hitKeys, hitValues, 0)
+			}
+		}
+		missing = next
+	}
+
+	return values, found, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilient
+
+import "unicode/utf8"
+
+// batch is the texts[start:end] sent in one request, holding tokens tokens.
+type batch struct {
+	start, end int
+	tokens     int
+}
+
+// split groups consecutive texts into batches within the item and token limits.
+func (e *Embedder) split(tokens []int) []batch {
+	var (
+		batches []batch
+		cur     batch
+	)
+	for i, n := range tokens {
+		full := e.maxBatchSize > 0 && cur.end-cur.start >= e.maxBatchSize
+		full = full || e.maxBatchTokens > 0 && cur.end > cur.start && cur.tokens+n > e.maxBatchTokens
+		if full {
+			batches = append(batches, cur)
+			cur = batch{start: i}
+		}
+		cur.end = i + 1
+		cur.tokens += n
+	}
+	if cur.end > cur.start {
+		batches = append(batches, cur)
+	}
+	return batches
+}
+
+// EstimateTokens is a tokenizer free estimate of the tokens of a text, counting about 4 ASCII characters
+// per token and a token per other character, which is conservative for CJK text with BPE tokenizers.
+func EstimateTokens(text string) int {
+	var ascii, other int
+	for i := 0; i < len(text); {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		other++
+		i += size
+	}
+	return other + (ascii+3)/4
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/components/embedding/resilient"
+)
+
+// lengthEmbedder stands in for a provider embedder, e.g. openai.NewEmbedder.
+type lengthEmbedder struct{}
+
+func (lengthEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	fmt.Printf("request with %d texts\n", len(texts))
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text))}
+	}
+	return vectors, nil
+}
+
+func main() {
+	ctx := context.Background()
+
+	embedder, err := resilient.NewEmbedder(ctx, &resilient.Config{
+		Embedder:          lengthEmbedder{},
+		MaxBatchSize:      2,
+		MaxBatchTokens:    8000,
+		Concurrency:       2,
+		RequestsPerMinute: 600,
+		InitialBackoff:    time.Second,
+	})
+	if err != nil {
+		log.Fatalf("NewEmbedder of resilient embedder failed, err=%v", err)
+	}
+
+	vectors, err := embedder.EmbedStrings(ctx, []string{"hello", "how are you", "fine", "thanks"})
+	if err != nil {
+		log.Fatalf("EmbedStrings of resilient embedder failed, err=%v", err)
+	}
+	fmt.Println(vectors)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket refilled at perMinute tokens per minute, holding at most perMinute tokens.
+// Callers reserve tokens in turn and wait until the bucket is back to zero, so a request larger than
+// the bucket is still admitted once the bucket is full.
+type limiter struct {
+	mu       sync.Mutex
+	capacity float64
+	perSec   float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// newLimiter returns nil, which never waits, if perMinute is zero.
+func newLimiter(perMinute int) *limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &limiter{
+		capacity: float64(perMinute),
+		perSec:   float64(perMinute) / 60,
+		tokens:   float64(perMinute),
+		now:      time.Now,
+	}
+}
+
+// wait reserves n tokens, blocking until they are available or ctx is done.
+func (l *limiter) wait(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+
+	delay := l.reserve(float64(n))
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(float64(n))
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) reserve(n float64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.perSec
+		if l.tokens > l.capacity {
+			l.tokens = l.capacity
+		}
+	}
+	l.last = now
+
+	// a request larger than the bucket only waits for a full bucket
+	if n > l.capacity {
+		n = l.capacity
+	}
+	l.tokens -= n
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.perSec * float64(time.Second))
+}
+
+// cancel returns the tokens of a reservation given up.
+func (l *limiter) cancel(n float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n > l.capacity {
+		n = l.capacity
+	}
+	l.tokens += n
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+}
@@ -0,0 +1,323 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const (
+	defaultConcurrency    = 1
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+const (
+	// Batches is the callback extra key of the number of requests the texts were split into.
+	Batches = "batches"
+	// Retries is the callback extra key of the number of retried requests.
+	Retries = "retries"
+)
+
+type Config struct {
+	// Embedder is the wrapped embedder, required.
+	Embedder embedding.Embedder
+
+	// MaxBatchSize is the maximum number of texts sent in one request, e.g. 200 for Tencent Cloud hunyuan
+	// or 2048 for OpenAI. Unlimited if zero.
+	MaxBatchSize int
+	// MaxBatchTokens is the maximum number of tokens sent in one request, counted by TokenCounter.
+	// A single text above the limit is sent alone. Unlimited if zero.
+	MaxBatchTokens int
+	// TokenCounter counts the tokens of a text, for MaxBatchTokens and TokensPerMinute.
+	// Optional. Default: EstimateTokens.
+	TokenCounter func(text string) int
+
+	// Concurrency is the number of requests sent in parallel, 1 by default.
+	Concurrency int
+	// RequestsPerMinute limits the rate of requests, including retries. Unlimited if zero.
+	RequestsPerMinute int
+	// TokensPerMinute limits the rate of tokens sent, including retries. Unlimited if zero.
+	TokensPerMinute int
+
+	// MaxRetries is the number of retries of a failed request.
+	// Optional. Default: 3, set it to 0 to disable retries.
+	MaxRetries *int
+	// InitialBackoff is the delay before the first retry, doubled for each following retry up to MaxBackoff.
+	// Every delay is jittered within its upper half, so concurrent callers don't retry in lockstep.
+	// Optional. Default: 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	// Optional. Default: 30s.
+	MaxBackoff time.Duration
+	// IsRetryable reports whether a failed request should be retried.
+	// Optional. Default: IsRetryableError, retrying rate limits, 5xx errors and timeouts.
+	IsRetryable func(err error) bool
+}
+
+// Embedder wraps an [embedding.Embedder], splitting the texts into batches sent concurrently,
+// under rate limits and with retries, and reassembling the embeddings in the order of the texts.
+type Embedder struct {
+	embedder       embedding.Embedder
+	maxBatchSize   int
+	maxBatchTokens int
+	countTokens    func(text string) int
+	concurrency    int
+	requests       *limiter
+	tokens         *limiter
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	isRetryable    func(err error) bool
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// NewEmbedder creates a new resilient [Embedder].
+func NewEmbedder(ctx context.Context, config *Config) (*Embedder, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Embedder == nil {
+		return nil, errors.New("embedder is required")
+	}
+	if config.MaxBatchSize < 0 || config.MaxBatchTokens < 0 || config.Concurrency < 0 ||
+		config.RequestsPerMinute < 0 || config.TokensPerMinute < 0 {
+		return nil, errors.New("batch limits, concurrency and rate limits must not be negative")
+	}
+
+	e := &Embedder{
+		embedder:       config.Embedder,
+		maxBatchSize:   config.MaxBatchSize,
+		maxBatchTokens: config.MaxBatchTokens,
+		countTokens:    config.TokenCounter,
+		concurrency:    config.Concurrency,
+		requests:       newLimiter(config.RequestsPerMinute),
+		tokens:         newLimiter(config.TokensPerMinute),
+		maxRetries:     defaultMaxRetries,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+		isRetryable:    config.IsRetryable,
+	}
+	if e.countTokens == nil {
+		e.countTokens = EstimateTokens
+	}
+	if e.concurrency == 0 {
+		e.concurrency = defaultConcurrency
+	}
+	if config.MaxRetries != nil {
+		if *config.MaxRetries < 0 {
+			return nil, errors.New("max retries must not be negative")
+		}
+		e.maxRetries = *config.MaxRetries
+	}
+	if e.initialBackoff <= 0 {
+		e.initialBackoff = defaultInitialBackoff
+	}
+	if e.maxBackoff <= 0 {
+		e.maxBackoff = defaultMaxBackoff
+	}
+	if e.isRetryable == nil {
+		e.isRetryable = IsRetryableError
+	}
+	return e, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	conf := &embedding.Config{}
+	if model := embedding.GetCommonOptions(nil, opts...).Model; model != nil {
+		conf.Model = *model
+	}
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+	})
+
+	tokens := make([]int, len(texts))
+	if e.maxBatchTokens > 0 || e.tokens != nil {
+		for i, text := range texts {
+			tokens[i] = e.countTokens(text)
+		}
+	}
+	batches := e.split(tokens)
+
+	embeddings, retries, err := e.embedBatches(e.makeEmbeddingCtx(ctx), texts, tokens, batches, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		Extra: map[string]any{
+			Batches: len(batches),
+			Retries: retries,
+		},
+	})
+
+	return embeddings, nil
+}
+
+func (e *Embedder) embedBatches(ctx context.Context, texts []string, tokens []int, batches []batch,
+	opts []embedding.Option) ([][]float64, int, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		vectors = make([][]float64, len(texts))
+		sem     = make(chan struct{}, e.concurrency)
+		wg      sync.WaitGroup
+		once    sync.Once
+		embErr  error
+		mu      sync.Mutex
+		retries int
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			embErr = err
+			cancel()
+		})
+	}
+
+	for _, b := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(b batch) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			v, n, err := e.embedWithRetry(ctx, texts[b.start:b.end], b.tokens, opts)
+			mu.Lock()
+			retries += n
+			mu.Unlock()
+			if err != nil {
+				fail(err)
+				return
+			}
+			copy(vectors[b.start:b.end], v)
+		}(b)
+	}
+	wg.Wait()
+
+	if embErr != nil {
+		return nil, retries, embErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, retries, err
+	}
+	return vectors, retries, nil
+}
+
+// embedWithRetry sends one batch, waiting for the rate limiters before every attempt,
+// and returns the number of retries done.
+func (e *Embedder) embedWithRetry(ctx context.Context, texts []string, tokens int,
+	opts []embedding.Option) ([][]float64, int, error) {
+
+	for attempt := 0; ; attempt++ {
+		if err := e.requests.wait(ctx, 1); err != nil {
+			return nil, attempt, err
+		}
+		if err := e.tokens.wait(ctx, tokens); err != nil {
+			return nil, attempt, err
+		}
+
+		vectors, err := e.embedder.EmbedStrings(ctx, texts, opts...)
+		if err == nil && len(vectors) != len(texts) {
+			return nil, attempt, fmt.Errorf("embedding returns %d vectors for %d texts", len(vectors), len(texts))
+		}
+		if err == nil {
+			return vectors, attempt, nil
+		}
+		if attempt >= e.maxRetries || ctx.Err() != nil || !e.isRetryable(err) {
+			return nil, attempt, fmt.Errorf("embed strings failed, attempts: %d: %w", attempt+1, err)
+		}
+
+		timer := time.NewTimer(e.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		}
+	}
+}
+
+// backoff returns the delay before the retry following the given attempt,
+// a random duration in the upper half of the exponential delay.
+func (e *Embedder) backoff(attempt int) time.Duration {
+	d := e.initialBackoff
+	for i := 0; i < attempt && d < e.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > e.maxBackoff {
+		d = e.maxBackoff
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// makeEmbeddingCtx reports the callbacks of the wrapped embedder under its own run info.
+func (e *Embedder) makeEmbeddingCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(e.embedder); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "Resilient"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
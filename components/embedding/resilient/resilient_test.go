@@ -0,0 +1,330 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+type fakeEmbedder struct {
+	mu       sync.Mutex
+	requests [][]string
+	// fail returns the error of a request, called with the number of requests sent so far for the first text
+	fail     func(texts []string, attempt int) error
+	attempts map[string]int
+	inflight atomic.Int32
+	peak     atomic.Int32
+	delay    time.Duration
+}
+
+func (f *fakeEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	n := f.inflight.Add(1)
+	defer f.inflight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if n <= peak || f.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, texts)
+	if f.attempts == nil {
+		f.attempts = map[string]int{}
+	}
+	attempt := f.attempts[texts[0]]
+	f.attempts[texts[0]]++
+	f.mu.Unlock()
+
+	if f.fail != nil {
+		if err := f.fail(texts, attempt); err != nil {
+			return nil, err
+		}
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text))}
+	}
+	return vectors, nil
+}
+
+func texts(n int) []string {
+	texts := make([]string, n)
+	for i := range texts {
+		texts[i] = strings.Repeat("a", i+1)
+	}
+	return texts
+}
+
+func TestEmbedder_EmbedStrings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("batches in order", func(t *testing.T) {
+		fe := &fakeEmbedder{delay: 10 * time.Millisecond}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxBatchSize: 3, Concurrency: 2})
+		require.NoError(t, err)
+
+		input := texts(10)
+		result, err := e.EmbedStrings(ctx, input)
+		require.NoError(t, err)
+		require.Len(t, result, 10)
+		for i := range input {
+			assert.Equal(t, []float64{float64(i + 1)}, result[i])
+		}
+		assert.Len(t, fe.requests, 4)
+		assert.Equal(t, int32(2), fe.peak.Load())
+
+		result, err = e.EmbedStrings(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+		assert.Len(t, fe.requests, 4)
+	})
+
+	t.Run("token limit", func(t *testing.T) {
+		fe := &fakeEmbedder{}
+		e, err := NewEmbedder(ctx, &Config{
+			Embedder:       fe,
+			MaxBatchTokens: 10,
+			TokenCounter:   func(text string) int { return len(text) },
+		})
+		require.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, []string{"aaaa", "bbbb", "cc", "d", "eeeeeeeeeeee", "ff"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"aaaa", "bbbb", "cc"}, {"d"}, {"eeeeeeeeeeee"}, {"ff"}}, fe.requests)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		fe := &fakeEmbedder{fail: func(texts []string, attempt int) error {
+			if attempt < 2 {
+				return errors.New("error, status code: 429, message: rate limited")
+			}
+			return nil
+		}}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, InitialBackoff: time.Millisecond})
+		require.NoError(t, err)
+
+		result, err := e.EmbedStrings(ctx, []string{"a"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}}, result)
+		assert.Len(t, fe.requests, 3)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		fe := &fakeEmbedder{fail: func(texts []string, attempt int) error {
+			return errors.New("503 Service Unavailable")
+		}}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxRetries: ptrOf(1), InitialBackoff: time.Millisecond})
+		require.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, []string{"a"})
+		assert.ErrorContains(t, err, "attempts: 2")
+		assert.Len(t, fe.requests, 2)
+	})
+
+	t.Run("not retryable error cancels other batches", func(t *testing.T) {
+		fe := &fakeEmbedder{fail: func(texts []string, attempt int) error {
+			if texts[0] == "a" {
+				return errors.New("invalid input")
+			}
+			return nil
+		}}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxBatchSize: 1})
+		require.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, texts(5))
+		assert.ErrorContains(t, err, "invalid input")
+		assert.Len(t, fe.requests, 1)
+	})
+
+	t.Run("wrong number of vectors", func(t *testing.T) {
+		e, err := NewEmbedder(ctx, &Config{Embedder: embedderFunc(func(texts []string) [][]float64 {
+			return nil
+		})})
+		require.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, []string{"a"})
+		assert.ErrorContains(t, err, "returns 0 vectors for 1 texts")
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		fe := &fakeEmbedder{}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxBatchSize: 1, RequestsPerMinute: 600})
+		require.NoError(t, err)
+		now := time.Now()
+		e.requests.tokens = 1
+		e.requests.last = now
+
+		start := time.Now()
+		_, err = e.EmbedStrings(ctx, texts(3))
+		require.NoError(t, err)
+		// one request is allowed right away, then one every 100ms
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	})
+
+	t.Run("callbacks", func(t *testing.T) {
+		fe := &fakeEmbedder{fail: func(texts []string, attempt int) error {
+			if texts[0] == "a" && attempt == 0 {
+				return errors.New("HTTP 502 Bad Gateway")
+			}
+			return nil
+		}}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxBatchSize: 2, InitialBackoff: time.Millisecond})
+		require.NoError(t, err)
+
+		var output *embedding.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				output = embedding.ConvCallbackOutput(out)
+				return ctx
+			}).
+			Build()
+		cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		_, err = e.EmbedStrings(cbCtx, texts(3), embedding.WithModel("m"))
+		require.NoError(t, err)
+		require.NotNil(t, output)
+		assert.Equal(t, "m", output.Config.Model)
+		assert.Equal(t, map[string]any{Batches: 2, Retries: 1}, output.Extra)
+	})
+}
+
+type embedderFunc func(texts []string) [][]float64
+
+func (f embedderFunc) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return f(texts), nil
+}
+
+func ptrOf[T any](v T) *T {
+	return &v
+}
+
+func TestNewEmbedder(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewEmbedder(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewEmbedder(ctx, &Config{})
+	assert.Error(t, err)
+	_, err = NewEmbedder(ctx, &Config{Embedder: &fakeEmbedder{}, MaxBatchSize: -1})
+	assert.Error(t, err)
+	_, err = NewEmbedder(ctx, &Config{Embedder: &fakeEmbedder{}, MaxRetries: ptrOf(-1)})
+	assert.Error(t, err)
+
+	e, err := NewEmbedder(ctx, &Config{Embedder: &fakeEmbedder{}, MaxRetries: ptrOf(0)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, e.maxRetries)
+	assert.Equal(t, defaultConcurrency, e.concurrency)
+	assert.Nil(t, e.requests)
+}
+
+func TestBackoff(t *testing.T) {
+	e, err := NewEmbedder(context.Background(), &Config{
+		Embedder:       &fakeEmbedder{},
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	})
+	require.NoError(t, err)
+
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			d := e.backoff(attempt)
+			assert.GreaterOrEqual(t, d, want/2)
+			assert.LessOrEqual(t, d, want)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(60)
+	l.now = func() time.Time { return now }
+
+	// the bucket starts full
+	assert.Zero(t, l.reserve(60))
+	assert.Equal(t, time.Second, l.reserve(1))
+	assert.Equal(t, 2*time.Second, l.reserve(1))
+
+	now = now.Add(3 * time.Second)
+	assert.Zero(t, l.reserve(1))
+
+	// larger than the bucket, waits for a full bucket only
+	assert.Equal(t, time.Minute, l.reserve(100))
+
+	// the canceled reservation is given back
+	l.cancel(100)
+	assert.Equal(t, time.Second, l.reserve(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.wait(ctx, 100), context.Canceled)
+
+	var nilLimiter *limiter
+	assert.NoError(t, nilLimiter.wait(ctx, 100))
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 3, EstimateTokens("hello world"))
+	assert.Equal(t, 4, EstimateTokens("你好世界"))
+	assert.Equal(t, 3, EstimateTokens("hi 你好"))
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestIsRetryableError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("invalid api key"), false},
+		{errors.New("input has 500 tokens, exceeding the limit"), false},
+		{errors.New("error, status code: 429, status: 429 Too Many Requests"), true},
+		{errors.New("Error code: 503 - upstream error"), true},
+		{errors.New("Rate limit reached for requests"), true},
+		{errors.New("request throttled"), true},
+		{fmt.Errorf("post failed: %w", timeoutError{}), true},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("wrapped: %w", context.Canceled), false},
+	} {
+		assert.Equal(t, tt.want, IsRetryableError(tt.err), "%v", tt.err)
+	}
+}
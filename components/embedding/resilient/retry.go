@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+)
+
+var (
+	// a status code following "status", "code" or "http", e.g. "status code: 429" or "HTTP 503"
+	retryableStatus = regexp.MustCompile(`(status|code|http)\D{0,16}\b(408|429|500|502|503|504)\b`)
+
+	retryableMessages = []string{
+		"too many requests",
+		"rate limit",
+		"ratelimit",
+		"throttl",
+		"quota exceeded",
+		"server overloaded",
+		"internal server error",
+		"bad gateway",
+		"service unavailable",
+		"gateway timeout",
+		"connection reset",
+		"connection refused",
+		"unexpected eof",
+	}
+)
+
+// IsRetryableError reports whether err looks transient: a timeout, a rate limit or a server error.
+// Embedders of different providers surface HTTP errors differently, mostly as plain messages,
+// so besides network timeouts it matches the status codes 408, 429, 500, 502, 503 and 504
+// and common rate limit and server error messages.
+// Cancellation of the caller's context is never retried.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		// a timeout of the request, the caller's deadline is checked before retrying
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	if retryableStatus.MatchString(msg) {
+		return true
+	}
+	for _, m := range retryableMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
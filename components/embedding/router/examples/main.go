@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/components/embedding/router"
+)
+
+// staticEmbedder stands in for a provider embedder, e.g. ark.NewEmbedder or ollama.NewEmbedder.
+type staticEmbedder struct {
+	err error
+}
+
+func (s staticEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i := range texts {
+		vectors[i] = []float64{0.6, 0.8}
+	}
+	return vectors, nil
+}
+
+func main() {
+	ctx := context.Background()
+
+	embedder, err := router.NewEmbedder(ctx, &router.Config{
+		Providers: []*router.Provider{
+			{Name: "ark", Embedder: staticEmbedder{err: errors.New("503 service unavailable")}, Model: "bge-m3", Dimensions: 2},
+			{Name: "ollama", Embedder: staticEmbedder{}, Model: "bge-m3:567m", Dimensions: 2, Compatible: true},
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewEmbedder of router embedder failed, err=%v", err)
+	}
+
+	handler := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if out := embedding.ConvCallbackOutput(output); out != nil && out.Extra != nil {
+				fmt.Printf("served by %v, failed: %v\n", out.Extra[router.ServedBy], out.Extra[router.FailedProviders])
+			}
+			return ctx
+		}).
+		Build()
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+	vectors, err := embedder.EmbedStrings(ctx, []string{"hello", "world"})
+	if err != nil {
+		log.Fatalf("EmbedStrings of router embedder failed, err=%v", err)
+	}
+	fmt.Println(vectors)
+}
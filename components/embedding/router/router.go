@@ -0,0 +1,383 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
+)
+
+const (
+	// ServedBy is the callback extra key of the name of the provider which returned the embeddings.
+	ServedBy = "served_by"
+	// FailedProviders is the callback extra key of the names of the providers tried before, in order.
+	FailedProviders = "failed_providers"
+)
+
+var (
+	// ErrCircuitOpen is reported for a provider skipped because it failed too often recently.
+	ErrCircuitOpen = errors.New("circuit open")
+	// ErrDimensionMismatch is reported for a provider returning vectors of an unexpected size.
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	// ErrModelOption is returned for a call with embedding.WithModel, the model of each provider is fixed
+	// by Provider.Model and a single model name can't be sent to providers of different platforms.
+	ErrModelOption = errors.New("embedding.WithModel is not supported by the router, set Provider.Model instead")
+
+	// errInvalidResponse is reported for a provider returning a vector count different from the text count.
+	errInvalidResponse = errors.New("invalid embedding response")
+)
+
+// Provider is an embedder the router can send requests to.
+type Provider struct {
+	// Name identifies the provider in errors and callbacks, required and unique.
+	Name string
+	// Embedder sends the requests, required.
+	Embedder embedding.Embedder
+	// Model is the model served by the provider, e.g. "doubao-embedding-text-240715".
+	// Fallback providers must serve the model of the primary one, unless Compatible is set.
+	Model string
+	// Dimensions is the size of the vectors returned, checked on every response if set.
+	// Providers can't differ in dimensions, vectors of different sizes can't be compared.
+	Dimensions int
+	// Compatible declares the vectors of this provider interchangeable with those of the primary provider
+	// although Model differs, e.g. the same open model served under different names by two platforms.
+	Compatible bool
+}
+
+type Config struct {
+	// Providers in priority order, the first one is the primary provider. Required.
+	Providers []*Provider
+	// FailureThreshold is the number of consecutive failures opening the circuit of a provider,
+	// which is then skipped for OpenDuration.
+	// Optional. Default: 5.
+	FailureThreshold int
+	// OpenDuration is how long a provider is skipped once its circuit is open.
+	// After it, a single request probes the provider, closing the circuit on success.
+	// Optional. Default: 30s.
+	OpenDuration time.Duration
+	// IsProviderError reports whether an error is a failure of the provider, counted by its circuit breaker
+	// and falling back to the next provider. Other errors, e.g. an invalid request, are returned at once.
+	// Vectors of an unexpected size or count are always failures of the provider.
+	// Optional. Default: IsProviderError, matching rate limits, 5xx errors and network failures.
+	IsProviderError func(err error) bool
+}
+
+// Embedder sends every call to the first available provider in priority order, falling back to the next one
+// on failure. All vectors of one call are served by a single provider.
+type Embedder struct {
+	providers       []*provider
+	isProviderError func(err error) bool
+	// dimensions is the size of the vectors, declared or learned from the first response, 0 if unknown
+	dimensions atomic.Int64
+}
+
+type provider struct {
+	*Provider
+	breaker *breaker
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// NewEmbedder creates a router [Embedder].
+func NewEmbedder(ctx context.Context, config *Config) (*Embedder, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if len(config.Providers) == 0 {
+		return nil, errors.New("at least one provider is required")
+	}
+	if config.FailureThreshold < 0 || config.OpenDuration < 0 {
+		return nil, errors.New("failure threshold and open duration must not be negative")
+	}
+
+	threshold := config.FailureThreshold
+	if threshold == 0 {
+		threshold = defaultFailureThreshold
+	}
+	openDuration := config.OpenDuration
+	if openDuration == 0 {
+		openDuration = defaultOpenDuration
+	}
+
+	e := &Embedder{isProviderError: config.IsProviderError}
+	if e.isProviderError == nil {
+		e.isProviderError = IsProviderError
+	}
+	primary := config.Providers[0]
+	names := make(map[string]bool, len(config.Providers))
+	for i, p := range config.Providers {
+		if p == nil || p.Name == "" || p.Embedder == nil {
+			return nil, fmt.Errorf("provider %d: name and embedder are required", i)
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("duplicate provider name: %s", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.Dimensions < 0 {
+			return nil, fmt.Errorf("provider %s: dimensions must not be negative", p.Name)
+		}
+		if p.Dimensions > 0 {
+			if dims := e.dimensions.Load(); dims != 0 && dims != int64(p.Dimensions) {
+				return nil, fmt.Errorf("provider %s: %w, %d dimensions instead of %d",
+					p.Name, ErrDimensionMismatch, p.Dimensions, dims)
+			}
+			e.dimensions.Store(int64(p.Dimensions))
+		}
+		if p.Model != primary.Model && !p.Compatible {
+			return nil, fmt.Errorf("provider %s serves model %q instead of %q, set Compatible if their vectors are interchangeable",
+				p.Name, p.Model, primary.Model)
+		}
+
+		e.providers = append(e.providers, &provider{
+			Provider: p,
+			breaker:  newBreaker(threshold, openDuration),
+		})
+	}
+	return e, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts: texts,
+	})
+
+	if embedding.GetCommonOptions(nil, opts...).Model != nil {
+		return nil, ErrModelOption
+	}
+
+	var (
+		errs   []error
+		failed []string
+	)
+	for _, p := range e.providers {
+		if !p.breaker.allow() {
+			errs = append(errs, fmt.Errorf("provider %s: %w", p.Name, ErrCircuitOpen))
+			continue
+		}
+
+		embeddings, err = e.embed(ctx, p, texts, opts)
+		if err == nil {
+			p.breaker.success()
+			callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+				Embeddings: embeddings,
+				Config:     &embedding.Config{Model: p.Model},
+				Extra: map[string]any{
+					ServedBy:        p.Name,
+					FailedProviders: failed,
+				},
+			})
+			return embeddings, nil
+		}
+
+		if ctx.Err() != nil {
+			// the caller gave up, which says nothing about the provider
+			p.breaker.cancel()
+			return nil, ctx.Err()
+		}
+		if !errors.Is(err, ErrDimensionMismatch) && !errors.Is(err, errInvalidResponse) && !e.isProviderError(err) {
+			// the request itself is wrong, the next provider would reject it as well
+			p.breaker.cancel()
+			return nil, fmt.Errorf("provider %s: %w", p.Name, err)
+		}
+		p.breaker.failure()
+		errs = append(errs, fmt.Errorf("provider %s: %w", p.Name, err))
+		failed = append(failed, p.Name)
+	}
+
+	return nil, fmt.Errorf("all embedding providers failed: %w", errors.Join(errs...))
+}
+
+func (e *Embedder) embed(ctx context.Context, p *provider, texts []string, opts []embedding.Option) ([][]float64, error) {
+	vectors, err := p.Embedder.EmbedStrings(makeEmbeddingCtx(ctx, p.Embedder), texts, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("%w, %d vectors for %d texts", errInvalidResponse, len(vectors), len(texts))
+	}
+	if len(vectors) == 0 {
+		return vectors, nil
+	}
+
+	want := e.dimensions.Load()
+	if want == 0 {
+		// no provider declares dimensions, the first response sets them
+		e.dimensions.CompareAndSwap(0, int64(len(vectors[0])))
+		want = e.dimensions.Load()
+	}
+	for _, v := range vectors {
+		if int64(len(v)) != want {
+			return nil, fmt.Errorf("%w, %d dimensions instead of %d", ErrDimensionMismatch, len(v), want)
+		}
+	}
+	return vectors, nil
+}
+
+// makeEmbeddingCtx reports the callbacks of a provider under its own run info.
+func makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "Router"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
+
+var (
+	// a status code following "status", "code" or "http", e.g. "status code: 429" or "HTTP 503"
+	providerStatus = regexp.MustCompile(`(status|code|http)\D{0,16}\b(408|429|500|502|503|504)\b`)
+
+	providerMessages = []string{
+		"too many requests",
+		"rate limit",
+		"ratelimit",
+		"throttl",
+		"quota exceeded",
+		"server overloaded",
+		"internal server error",
+		"bad gateway",
+		"service unavailable",
+		"gateway timeout",
+		"connection reset",
+		"connection refused",
+		"unexpected eof",
+	}
+)
+
+// IsProviderError reports whether err is a failure of the provider rather than of the request:
+// a network error, a timeout, a rate limit or a server error. Embedders surface HTTP errors differently,
+// mostly as plain messages, so it matches the status codes 408, 429, 500, 502, 503 and 504
+// and common rate limit and server error messages, as the resilient embedder does for retries.
+func IsProviderError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	if providerStatus.MatchString(msg) {
+		return true
+	}
+	for _, m := range providerMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// breaker is the circuit breaker of a provider: closed while failures are below threshold, then open for
+// openDuration, then half open, letting a single probe through whose result closes or reopens the circuit.
+type breaker struct {
+	mu           sync.Mutex
+	threshold    int
+	openDuration time.Duration
+	failures     int
+	openUntil    time.Time
+	probing      bool
+	now          func() time.Time
+}
+
+func newBreaker(threshold int, openDuration time.Duration) *breaker {
+	return &breaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+		now:          time.Now,
+	}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.openDuration)
+	}
+}
+
+// cancel releases a probe whose result is unknown.
+func (b *breaker) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+type fakeEmbedder struct {
+	dims  int
+	err   error
+	calls int
+}
+
+func (f *fakeEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i := range texts {
+		vectors[i] = make([]float64, f.dims)
+	}
+	return vectors, nil
+}
+
+func TestNewEmbedder(t *testing.T) {
+	ctx := context.Background()
+	emb := &fakeEmbedder{dims: 2}
+
+	for _, tt := range []struct {
+		name      string
+		providers []*Provider
+		err       string
+	}{
+		{"no provider", nil, "at least one provider"},
+		{"no name", []*Provider{{Embedder: emb}}, "name and embedder are required"},
+		{"duplicate name", []*Provider{{Name: "a", Embedder: emb}, {Name: "a", Embedder: emb}}, "duplicate provider name"},
+		{
+			"different model",
+			[]*Provider{{Name: "ark", Embedder: emb, Model: "m1"}, {Name: "ollama", Embedder: emb, Model: "m2"}},
+			`provider ollama serves model "m2" instead of "m1"`,
+		},
+		{
+			"different dimensions",
+			[]*Provider{
+				{Name: "ark", Embedder: emb, Model: "m1", Dimensions: 1024},
+				{Name: "ollama", Embedder: emb, Model: "m2", Dimensions: 768, Compatible: true},
+			},
+			"dimension mismatch",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmbedder(ctx, &Config{Providers: tt.providers})
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	_, err := NewEmbedder(ctx, &Config{Providers: []*Provider{
+		{Name: "ark", Embedder: emb, Model: "m1"},
+		{Name: "openai", Embedder: emb, Model: "m1", Dimensions: 2},
+		{Name: "ollama", Embedder: emb, Model: "m2", Compatible: true},
+	}})
+	assert.NoError(t, err)
+}
+
+func TestEmbedder_Failover(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeEmbedder{dims: 2, err: errors.New("503 service unavailable")}
+	secondary := &fakeEmbedder{dims: 2}
+
+	e, err := NewEmbedder(ctx, &Config{
+		Providers: []*Provider{
+			{Name: "ark", Embedder: primary, Model: "m"},
+			{Name: "openai", Embedder: secondary, Model: "m"},
+		},
+		FailureThreshold: 2,
+		OpenDuration:     time.Minute,
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	e.providers[0].breaker.now = func() time.Time { return now }
+
+	var outputs []*embedding.CallbackOutput
+	handler := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+			outputs = append(outputs, embedding.ConvCallbackOutput(out))
+			return ctx
+		}).
+		Build()
+	cbCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+	for i := 0; i < 3; i++ {
+		vectors, err := e.EmbedStrings(cbCtx, []string{"a", "b"})
+		require.NoError(t, err)
+		assert.Len(t, vectors, 2)
+	}
+	// the circuit opened after two failures, the third call skipped the primary provider
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 3, secondary.calls)
+	require.Len(t, outputs, 3)
+	assert.Equal(t, map[string]any{ServedBy: "openai", FailedProviders: []string{"ark"}}, outputs[0].Extra)
+	assert.Equal(t, map[string]any{ServedBy: "openai", FailedProviders: []string(nil)}, outputs[2].Extra)
+	assert.Equal(t, "m", outputs[2].Config.Model)
+
+	// after the open duration, a probe closes the circuit
+	primary.err = nil
+	now = now.Add(time.Minute)
+	_, err = e.EmbedStrings(cbCtx, []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, primary.calls)
+	assert.Equal(t, "ark", outputs[3].Extra[ServedBy])
+	assert.True(t, e.providers[0].breaker.allow())
+}
+
+func TestEmbedder_AllFailed(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeEmbedder{err: errors.New("500 internal server error")}
+	secondary := &fakeEmbedder{dims: 3}
+
+	e, err := NewEmbedder(ctx, &Config{
+		Providers: []*Provider{
+			{Name: "ark", Embedder: primary, Dimensions: 2},
+			{Name: "ollama", Embedder: secondary},
+		},
+		FailureThreshold: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	assert.ErrorContains(t, err, "provider ark: 500 internal server error")
+	assert.ErrorContains(t, err, "provider ollama: dimension mismatch, 3 dimensions instead of 2")
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+
+	// both circuits are open now
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, 1, secondary.calls)
+}
+
+func TestEmbedder_LearnDimensions(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeEmbedder{dims: 4}
+	secondary := &fakeEmbedder{dims: 8}
+
+	e, err := NewEmbedder(ctx, &Config{Providers: []*Provider{
+		{Name: "ark", Embedder: primary},
+		{Name: "ollama", Embedder: secondary},
+	}})
+	require.NoError(t, err)
+
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	require.NoError(t, err)
+
+	// the fallback provider returns vectors of another size, which are refused
+	primary.err = errors.New("gateway timeout")
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+}
+
+func TestEmbedder_RequestError(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeEmbedder{err: errors.New("400 bad request: input too long")}
+	secondary := &fakeEmbedder{dims: 2}
+
+	e, err := NewEmbedder(ctx, &Config{
+		Providers:        []*Provider{{Name: "ark", Embedder: primary}, {Name: "ollama", Embedder: secondary}},
+		FailureThreshold: 1,
+	})
+	require.NoError(t, err)
+
+	// an invalid request neither falls back nor opens the circuit
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	assert.EqualError(t, err, "provider ark: 400 bad request: input too long")
+	assert.Equal(t, 0, secondary.calls)
+	assert.True(t, e.providers[0].breaker.allow())
+
+	e, err = NewEmbedder(ctx, &Config{
+		Providers:        []*Provider{{Name: "ark", Embedder: primary}, {Name: "ollama", Embedder: secondary}},
+		FailureThreshold: 1,
+		IsProviderError:  func(err error) bool { return true },
+	})
+	require.NoError(t, err)
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, secondary.calls)
+	assert.False(t, e.providers[0].breaker.allow())
+}
+
+func TestEmbedder_ModelOption(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeEmbedder{dims: 2}
+
+	e, err := NewEmbedder(ctx, &Config{Providers: []*Provider{{Name: "ark", Embedder: primary, Model: "m"}}})
+	require.NoError(t, err)
+
+	_, err = e.EmbedStrings(ctx, []string{"a"}, embedding.WithModel("other"))
+	assert.ErrorIs(t, err, ErrModelOption)
+	assert.Equal(t, 0, primary.calls)
+}
+
+func TestEmbedder_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	primary := &fakeEmbedder{err: context.Canceled}
+	secondary := &fakeEmbedder{dims: 2}
+
+	e, err := NewEmbedder(ctx, &Config{
+		Providers:        []*Provider{{Name: "ark", Embedder: primary}, {Name: "ollama", Embedder: secondary}},
+		FailureThreshold: 1,
+	})
+	require.NoError(t, err)
+
+	cancel()
+	_, err = e.EmbedStrings(ctx, []string{"a"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, secondary.calls)
+	assert.True(t, e.providers[0].breaker.allow())
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, time.Second)
+	b.now = func() time.Time { return now }
+
+	b.failure()
+	assert.True(t, b.allow())
+	b.failure()
+	assert.False(t, b.allow())
+
+	now = now.Add(time.Second)
+	assert.True(t, b.allow())
+	// a single probe at a time
+	assert.False(t, b.allow())
+	b.failure()
+	assert.False(t, b.allow())
+
+	now = now.Add(time.Second)
+	assert.True(t, b.allow())
+	b.success()
+	assert.True(t, b.allow())
+	assert.True(t, b.allow())
+}
+
+func TestIsProviderError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, true},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{errors.New("error, status code: 429, message: too many requests"), true},
+		{errors.New("HTTP 503"), true},
+		{errors.New("status code: 400, invalid input"), false},
+		{errors.New("model not found"), false},
+	} {
+		assert.Equal(t, tt.want, IsProviderError(tt.err), "%v", tt.err)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileStore keeps records in a local JSON file, which is rewritten atomically on every change.
+type FileStore struct {
+	path string
+
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore creates a FileStore backed by the file at path, loading the existing records if the file exists.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		path:    path,
+		records: make(map[string]Record),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("read record file failed: %w", err)
+	}
+
+	var records []Record
+	if err = json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("unmarshal record file failed: %w", err)
+	}
+	for _, r := range records {
+		s.records[r.ID] = r
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Get(ctx context.Context, ids []string) (map[string]*Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make(map[string]*Record, len(ids))
+	for _, id := range ids {
+		if r, ok := s.records[id]; ok {
+			res[id] = &r
+		}
+	}
+	return res, nil
+}
+
+func (s *FileStore) Upsert(ctx context.Context, records []*Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := make(map[string]*Record, len(records))
+	for _, r := range records {
+		if old, ok := s.records[r.ID]; ok {
+			prev[r.ID] = &old
+		} else {
+			prev[r.ID] = nil
+		}
+		s.records[r.ID] = *r
+	}
+
+	if err := s.flush(); err != nil {
+		s.restore(prev)
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) ListIDsBySource(ctx context.Context, source string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for id, r := range s.records {
+		if r.Source == source {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := make(map[string]*Record, len(ids))
+	for _, id := range ids {
+		if old, ok := s.records[id]; ok {
+			prev[id] = &old
+			delete(s.records, id)
+		}
+	}
+	if len(prev) == 0 {
+		return nil
+	}
+
+	if err := s.flush(); err != nil {
+		s.restore(prev)
+		return err
+	}
+	return nil
+}
+
+// restore rolls back in-memory changes when the file could not be written.
+func (s *FileStore) restore(prev map[string]*Record) {
+	for id, r := range prev {
+		if r == nil {
+			delete(s.records, id)
+		} else {
+			s.records[id] = *r
+		}
+	}
+}
+
+func (s *FileStore) flush() error {
+	records := make([]Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal records failed: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp record file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write temp record file failed: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close temp record file failed: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("rename record file failed: %w", err)
+	}
+	return nil
+}
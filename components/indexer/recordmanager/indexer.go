@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	// Indexer stores the new and changed documents, required.
+	Indexer indexer.Indexer
+	// Manager decides which documents need to be stored, required.
+	Manager *Manager
+	// OnStale is called by Cleanup with the stale ids, usually to delete them from the underlying index.
+	// The stale records are forgotten once it returns nil. Required by Cleanup.
+	OnStale func(ctx context.Context, ids []string) error
+}
+
+// Indexer wraps an indexer.Indexer so that only new or changed documents are stored.
+type Indexer struct {
+	indexer indexer.Indexer
+	manager *Manager
+	onStale func(ctx context.Context, ids []string) error
+}
+
+var _ indexer.Indexer = (*Indexer)(nil)
+
+// NewIndexer creates a new Indexer.
+func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config == nil || config.Indexer == nil {
+		return nil, errors.New("record manager indexer is required")
+	}
+	if config.Manager == nil {
+		return nil, errors.New("record manager is required")
+	}
+
+	return &Indexer{
+		indexer: config.Indexer,
+		manager: config.Manager,
+		onStale: config.OnStale,
+	}, nil
+}
+
+// Store stores the changed documents with the underlying indexer and records them.
+// The returned ids are in input order, unchanged documents keep their own id.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	res, err := i.manager.Filter(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	storedIDs := make(map[*schema.Document]string, len(res.Changed))
+	if len(res.Changed) > 0 {
+		changedIDs, err := i.indexer.Store(ctx, res.Changed, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if len(changedIDs) != len(res.Changed) {
+			return nil, fmt.Errorf("indexer returned %d ids for %d documents", len(changedIDs), len(res.Changed))
+		}
+		for idx, doc := range res.Changed {
+			storedIDs[doc] = changedIDs[idx]
+		}
+
+		if err = i.manager.Commit(ctx, res.Changed); err != nil {
+			return nil, err
+		}
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if id, ok := storedIDs[doc]; ok {
+			ids = append(ids, id)
+		} else {
+			ids = append(ids, doc.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// Cleanup passes the stale ids of sources to OnStale once every Store call of a run is done,
+// see [Manager.Cleanup]. Stale documents are never deleted by Store, as the documents of a source
+// may be spread over several Store calls.
+func (i *Indexer) Cleanup(ctx context.Context, sources ...string) ([]string, error) {
+	if i.onStale == nil {
+		return nil, errors.New("record manager indexer OnStale is required to clean up")
+	}
+	return i.manager.Cleanup(ctx, sources, i.onStale)
+}
+
+func (i *Indexer) GetType() string {
+	return "RecordManagerIndexer"
+}
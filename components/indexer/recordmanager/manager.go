@@ -0,0 +1,253 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeySource is the metadata key loaders and parsers use for the source uri.
+	MetaKeySource = "_source"
+)
+
+type Config struct {
+	// Store persists the records, required.
+	Store Store
+	// SourceKey is the metadata key holding the source uri of a document, default "_source".
+	SourceKey string
+	// HashFunc computes the hash used for change detection, default to sha256 of the content.
+	HashFunc func(doc *schema.Document) string
+}
+
+// Manager tracks ingested documents by source uri and content hash,
+// so that only new or changed documents are stored again and documents removed from a source can be deleted.
+type Manager struct {
+	store     Store
+	sourceKey string
+	hashFunc  func(doc *schema.Document) string
+
+	mu sync.Mutex
+	// seen holds the ids passed to Filter since the last Cleanup, by source.
+	seen map[string]map[string]bool
+}
+
+// FilterResult is the outcome of comparing a batch of documents with the records.
+type FilterResult struct {
+	// Changed are the documents that are new or whose content hash changed, in input order.
+	Changed []*schema.Document
+	// Unchanged are the documents already recorded with the same content hash, in input order.
+	Unchanged []*schema.Document
+}
+
+// NewManager creates a new Manager.
+func NewManager(ctx context.Context, config *Config) (*Manager, error) {
+	if config == nil || config.Store == nil {
+		return nil, errors.New("record manager store is required")
+	}
+
+	m := &Manager{
+		store:     config.Store,
+		sourceKey: config.SourceKey,
+		hashFunc:  config.HashFunc,
+		seen:      make(map[string]map[string]bool),
+	}
+	if m.sourceKey == "" {
+		m.sourceKey = MetaKeySource
+	}
+	if m.hashFunc == nil {
+		m.hashFunc = ContentHash
+	}
+
+	return m, nil
+}
+
+// ContentHash is the default hash function, the hex sha256 of the document content.
+func ContentHash(doc *schema.Document) string {
+	sum := sha256.Sum256([]byte(doc.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Filter splits docs into changed and unchanged documents.
+// The documents of a source may be spread over several batches, Filter remembers the ids it has seen
+// for Cleanup to find the documents no longer part of their source.
+// Filter does not modify the records, call Commit once the changed documents are stored.
+func (m *Manager) Filter(ctx context.Context, docs []*schema.Document) (*FilterResult, error) {
+	ids, err := m.collect(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := m.store.Get(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get records failed: %w", err)
+	}
+
+	m.mu.Lock()
+	for _, doc := range docs {
+		source := m.source(doc)
+		if source == "" {
+			continue
+		}
+		if m.seen[source] == nil {
+			m.seen[source] = make(map[string]bool)
+		}
+		m.seen[source][doc.ID] = true
+	}
+	m.mu.Unlock()
+
+	res := &FilterResult{}
+	for _, doc := range docs {
+		if r, ok := records[doc.ID]; ok && r.Hash == m.hashFunc(doc) && r.Source == m.source(doc) {
+			res.Unchanged = append(res.Unchanged, doc)
+		} else {
+			res.Changed = append(res.Changed, doc)
+		}
+	}
+
+	return res, nil
+}
+
+// Cleanup finds the stale ids of sources: the ids recorded for a source that no Filter call has seen
+// since the last Cleanup of the source. Call it once all current documents of the sources went through Filter,
+// however many batches they were split into.
+// deleteFn is called with the stale ids, usually to delete them from the index, and their records
+// are forgotten once it returns nil. The stale ids are returned.
+// Empty sources means every source seen since the last Cleanup, list the sources explicitly to also clean up
+// sources without any document left, e.g. deleted files.
+func (m *Manager) Cleanup(ctx context.Context, sources []string,
+	deleteFn func(ctx context.Context, ids []string) error) ([]string, error) {
+
+	m.mu.Lock()
+	if len(sources) == 0 {
+		for source := range m.seen {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+	}
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		for id := range m.seen[source] {
+			seen[id] = true
+		}
+	}
+	m.mu.Unlock()
+
+	var stale []string
+	for _, source := range sources {
+		recorded, err := m.store.ListIDsBySource(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("list records of source [%s] failed: %w", source, err)
+		}
+		for _, id := range recorded {
+			if !seen[id] {
+				seen[id] = true
+				stale = append(stale, id)
+			}
+		}
+	}
+
+	if len(stale) > 0 {
+		if err := deleteFn(ctx, stale); err != nil {
+			return nil, fmt.Errorf("delete stale ids failed: %w", err)
+		}
+		if err := m.Forget(ctx, stale); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	for _, source := range sources {
+		delete(m.seen, source)
+	}
+	m.mu.Unlock()
+
+	return stale, nil
+}
+
+// Commit records docs with their current content hash.
+func (m *Manager) Commit(ctx context.Context, docs []*schema.Document) error {
+	if _, err := m.collect(docs); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	records := make([]*Record, 0, len(docs))
+	for _, doc := range docs {
+		records = append(records, &Record{
+			ID:        doc.ID,
+			Source:    m.source(doc),
+			Hash:      m.hashFunc(doc),
+			UpdatedAt: now,
+		})
+	}
+
+	if err := m.store.Upsert(ctx, records); err != nil {
+		return fmt.Errorf("upsert records failed: %w", err)
+	}
+	return nil
+}
+
+// Forget removes the records of ids, typically the stale ids once they are deleted from the indexer.
+func (m *Manager) Forget(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := m.store.Delete(ctx, ids); err != nil {
+		return fmt.Errorf("delete records failed: %w", err)
+	}
+	return nil
+}
+
+// collect validates the documents and returns their ids in input order.
+func (m *Manager) collect(docs []*schema.Document) (ids []string, err error) {
+	seenIDs := make(map[string]bool, len(docs))
+	for i, doc := range docs {
+		if doc == nil {
+			return nil, fmt.Errorf("document at index %d is nil", i)
+		}
+		if doc.ID == "" {
+			return nil, fmt.Errorf("document at index %d has no id", i)
+		}
+		if seenIDs[doc.ID] {
+			return nil, fmt.Errorf("duplicate document id [%s]", doc.ID)
+		}
+		seenIDs[doc.ID] = true
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+func (m *Manager) source(doc *schema.Document) string {
+	v, ok := doc.MetaData[m.sourceKey]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type mockIndexer struct {
+	stored [][]string
+	err    error
+}
+
+func (m *mockIndexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	m.stored = append(m.stored, ids)
+	return ids, nil
+}
+
+func newDoc(id, source, content string) *schema.Document {
+	return &schema.Document{
+		ID:       id,
+		Content:  content,
+		MetaData: map[string]any{MetaKeySource: source},
+	}
+}
+
+func docIDs(docs []*schema.Document) []string {
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewManager(ctx, nil)
+	assert.Error(t, err)
+
+	m, err := NewManager(ctx, &Config{Store: NewMemoryStore()})
+	assert.NoError(t, err)
+
+	batch := []*schema.Document{
+		newDoc("a_0", "a.md", "a0"),
+		newDoc("a_1", "a.md", "a1"),
+		newDoc("b_0", "b.md", "b0"),
+	}
+	res, err := m.Filter(ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0", "a_1", "b_0"}, docIDs(res.Changed))
+	assert.Empty(t, res.Unchanged)
+	assert.NoError(t, m.Commit(ctx, res.Changed))
+	noDelete := func(ctx context.Context, ids []string) error {
+		t.Errorf("unexpected stale ids %v", ids)
+		return nil
+	}
+	stale, err := m.Cleanup(ctx, nil, noDelete)
+	assert.NoError(t, err)
+	assert.Empty(t, stale)
+
+	// a.md shrank to one changed chunk, b.md is untouched.
+	batch = []*schema.Document{
+		newDoc("a_0", "a.md", "a0 changed"),
+		newDoc("b_0", "b.md", "b0"),
+	}
+	res, err = m.Filter(ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0"}, docIDs(res.Changed))
+	assert.Equal(t, []string{"b_0"}, docIDs(res.Unchanged))
+	assert.NoError(t, m.Commit(ctx, res.Changed))
+
+	var deleted []string
+	stale, err = m.Cleanup(ctx, nil, func(ctx context.Context, ids []string) error {
+		deleted = append(deleted, ids...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_1"}, stale)
+	assert.Equal(t, []string{"a_1"}, deleted)
+
+	// the chunks of a source spread over several batches are not stale.
+	for _, doc := range []*schema.Document{newDoc("a_0", "a.md", "a0 changed"), newDoc("a_1", "a.md", "a1")} {
+		res, err = m.Filter(ctx, []*schema.Document{doc})
+		assert.NoError(t, err)
+		assert.NoError(t, m.Commit(ctx, res.Changed))
+	}
+	stale, err = m.Cleanup(ctx, nil, noDelete)
+	assert.NoError(t, err)
+	assert.Empty(t, stale)
+
+	// a source listed explicitly without any document left is cleaned up, a failed delete keeps the records.
+	deleteErr := errors.New("delete failed")
+	_, err = m.Cleanup(ctx, []string{"b.md"}, func(ctx context.Context, ids []string) error { return deleteErr })
+	assert.ErrorIs(t, err, deleteErr)
+	stale, err = m.Cleanup(ctx, []string{"b.md"}, func(ctx context.Context, ids []string) error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b_0"}, stale)
+	records, err := m.store.Get(ctx, []string{"b_0"})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	// a document moved to another source counts as changed.
+	res, err = m.Filter(ctx, []*schema.Document{newDoc("b_0", "c.md", "b0")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b_0"}, docIDs(res.Changed))
+
+	_, err = m.Filter(ctx, []*schema.Document{newDoc("", "a.md", "x")})
+	assert.Error(t, err)
+	_, err = m.Filter(ctx, []*schema.Document{newDoc("x", "a.md", "x"), newDoc("x", "a.md", "y")})
+	assert.Error(t, err)
+}
+
+func TestIndexer(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	m, err := NewManager(ctx, &Config{Store: store})
+	assert.NoError(t, err)
+
+	var deleted []string
+	inner := &mockIndexer{}
+	idx, err := NewIndexer(ctx, &IndexerConfig{
+		Indexer: inner,
+		Manager: m,
+		OnStale: func(ctx context.Context, ids []string) error {
+			deleted = append(deleted, ids...)
+			return nil
+		},
+	})
+	assert.NoError(t, err)
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		newDoc("a_0", "a.md", "a0"),
+		newDoc("a_1", "a.md", "a1"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0", "a_1"}, ids)
+	stale, err := idx.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, stale)
+
+	// the next run stores a.md in two calls without a_1.
+	ids, err = idx.Store(ctx, []*schema.Document{
+		newDoc("a_0", "a.md", "a0"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0"}, ids)
+	_, err = idx.Store(ctx, []*schema.Document{
+		newDoc("a_2", "a.md", "a2"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a_0", "a_1"}, {"a_2"}}, inner.stored)
+	// Store never deletes, the chunks of a.md were spread over two calls.
+	assert.Empty(t, deleted)
+
+	stale, err = idx.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_1"}, stale)
+	assert.Equal(t, []string{"a_1"}, deleted)
+
+	ids, err = store.ListIDsBySource(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0", "a_2"}, ids)
+
+	// nothing changed, the inner indexer is not called.
+	_, err = idx.Store(ctx, []*schema.Document{
+		newDoc("a_0", "a.md", "a0"),
+		newDoc("a_2", "a.md", "a2"),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, inner.stored, 2)
+
+	// records are not written when the inner indexer fails.
+	inner.err = errors.New("store failed")
+	_, err = idx.Store(ctx, []*schema.Document{newDoc("a_0", "a.md", "a0 changed")})
+	assert.Error(t, err)
+	records, err := store.Get(ctx, []string{"a_0"})
+	assert.NoError(t, err)
+	assert.Equal(t, ContentHash(&schema.Document{Content: "a0"}), records["a_0"].Hash)
+
+	withoutOnStale, err := NewIndexer(ctx, &IndexerConfig{Indexer: inner, Manager: m})
+	assert.NoError(t, err)
+	_, err = withoutOnStale.Cleanup(ctx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/indexer/recordmanager"
+)
+
+const (
+	fieldSource    = "source"
+	fieldHash      = "hash"
+	fieldUpdatedAt = "updated_at"
+)
+
+// Store keeps records in redis, each record is a hash at "<prefix>record:<id>"
+// and the ids of each source are a set at "<prefix>source:<source>".
+type Store struct {
+	rdb    redis.UniversalClient
+	prefix string
+}
+
+type Option interface {
+	apply(*Store)
+}
+
+type optionFunc func(*Store)
+
+func (f optionFunc) apply(s *Store) {
+	f(s)
+}
+
+// WithPrefix returns an [Option] that sets the key prefix of the [Store], default "eino:records:".
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(s *Store) {
+		s.prefix = strings.TrimSuffix(prefix, ":") + ":"
+	})
+}
+
+var _ recordmanager.Store = (*Store)(nil)
+
+// NewStore creates a new redis [Store].
+func NewStore(rdb redis.UniversalClient, opts ...Option) *Store {
+	s := &Store{
+		rdb:    rdb,
+		prefix: "eino:records:",
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
+
+func (s *Store) Get(ctx context.Context, ids []string) (map[string]*recordmanager.Record, error) {
+	if len(ids) == 0 {
+		return map[string]*recordmanager.Record{}, nil
+	}
+
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HGetAll(ctx, s.recordKey(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*recordmanager.Record, len(ids))
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			continue
+		}
+		updatedAt, _ := strconv.ParseInt(fields[fieldUpdatedAt], 10, 64)
+		res[ids[i]] = &recordmanager.Record{
+			ID:        ids[i],
+			Source:    fields[fieldSource],
+			Hash:      fields[fieldHash],
+			UpdatedAt: time.UnixMilli(updatedAt),
+		}
+	}
+	return res, nil
+}
+
+func (s *Store) Upsert(ctx context.Context, records []*recordmanager.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.ID
+	}
+	oldSources, err := s.sources(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, r := range records {
+			if old, ok := oldSources[r.ID]; ok && old != r.Source {
+				pipe.SRem(ctx, s.sourceKey(old), r.ID)
+			}
+			pipe.HSet(ctx, s.recordKey(r.ID),
+				fieldSource, r.Source,
+				fieldHash, r.Hash,
+				fieldUpdatedAt, r.UpdatedAt.UnixMilli(),
+			)
+			pipe.SAdd(ctx, s.sourceKey(r.Source), r.ID)
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *Store) ListIDsBySource(ctx context.Context, source string) ([]string, error) {
+	ids, err := s.rdb.SMembers(ctx, s.sourceKey(source)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *Store) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	oldSources, err := s.sources(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Del(ctx, s.recordKey(id))
+			if old, ok := oldSources[id]; ok {
+				pipe.SRem(ctx, s.sourceKey(old), id)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// sources returns the recorded source of each existing id.
+func (s *Store) sources(ctx context.Context, ids []string) (map[string]string, error) {
+	cmds := make([]*redis.StringCmd, len(ids))
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HGet(ctx, s.recordKey(id), fieldSource)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(ids))
+	for i, cmd := range cmds {
+		source, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res[ids[i]] = source
+	}
+	return res, nil
+}
+
+func (s *Store) recordKey(id string) string {
+	return s.prefix + "record:" + id
+}
+
+func (s *Store) sourceKey(source string) string {
+	return s.prefix + "source:" + source
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/recordmanager"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	s := NewStore(rdb, WithPrefix("test"))
+	now := time.UnixMilli(time.Now().UnixMilli())
+
+	assert.NoError(t, s.Upsert(ctx, []*recordmanager.Record{
+		{ID: "a_0", Source: "a.md", Hash: "h0", UpdatedAt: now},
+		{ID: "a_1", Source: "a.md", Hash: "h1", UpdatedAt: now},
+		{ID: "b_0", Source: "b.md", Hash: "h2", UpdatedAt: now},
+	}))
+	assert.True(t, mr.Exists("test:record:a_0"))
+	assert.True(t, mr.Exists("test:source:a.md"))
+
+	records, err := s.Get(ctx, []string{"a_0", "b_0", "missing"})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "a.md", records["a_0"].Source)
+	assert.Equal(t, "h2", records["b_0"].Hash)
+	assert.True(t, now.Equal(records["b_0"].UpdatedAt))
+
+	// moving a record to another source updates both source sets.
+	assert.NoError(t, s.Upsert(ctx, []*recordmanager.Record{{ID: "a_1", Source: "b.md", Hash: "h1", UpdatedAt: now}}))
+	ids, err := s.ListIDsBySource(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0"}, ids)
+	ids, err = s.ListIDsBySource(ctx, "b.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_1", "b_0"}, ids)
+
+	assert.NoError(t, s.Delete(ctx, []string{"a_1", "missing"}))
+	ids, err = s.ListIDsBySource(ctx, "b.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b_0"}, ids)
+	assert.False(t, mr.Exists("test:record:a_1"))
+
+	ids, err = s.ListIDsBySource(ctx, "none.md")
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestStoreWithManager(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	m, err := recordmanager.NewManager(ctx, &recordmanager.Config{Store: NewStore(rdb)})
+	assert.NoError(t, err)
+
+	docs := []*schema.Document{
+		{ID: "a_0", Content: "a0", MetaData: map[string]any{recordmanager.MetaKeySource: "a.md"}},
+		{ID: "a_1", Content: "a1", MetaData: map[string]any{recordmanager.MetaKeySource: "a.md"}},
+	}
+	assert.NoError(t, m.Commit(ctx, docs))
+
+	res, err := m.Filter(ctx, docs[:1])
+	assert.NoError(t, err)
+	assert.Empty(t, res.Changed)
+	assert.Len(t, res.Unchanged, 1)
+
+	stale, err := m.Cleanup(ctx, nil, func(ctx context.Context, ids []string) error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_1"}, stale)
+	ids, err := NewStore(rdb).ListIDsBySource(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0"}, ids)
+}
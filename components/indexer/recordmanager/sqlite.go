@@ -0,0 +1,174 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSQLiteTable = "eino_records"
+	sqliteBatchSize    = 500
+)
+
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLiteStore keeps records in a SQLite table through database/sql.
+// The caller opens the *sql.DB with the driver of its choice, e.g. github.com/mattn/go-sqlite3 or modernc.org/sqlite.
+type SQLiteStore struct {
+	db    *sql.DB
+	table string
+}
+
+var _ Store = (*SQLiteStore)(nil)
+
+// NewSQLiteStore creates a SQLiteStore, creating the table and its source index if they don't exist.
+// The table defaults to "eino_records".
+func NewSQLiteStore(ctx context.Context, db *sql.DB, table string) (*SQLiteStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("sqlite store db is required")
+	}
+	if table == "" {
+		table = defaultSQLiteTable
+	}
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid sqlite table name: %s", table)
+	}
+
+	stmts := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	source TEXT NOT NULL,
+	hash TEXT NOT NULL,
+	updated_at INTEGER NOT NULL
+)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_source_idx ON %s (source)`, table, table),
+	}
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("create sqlite record table failed: %w", err)
+		}
+	}
+
+	return &SQLiteStore{db: db, table: table}, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, ids []string) (map[string]*Record, error) {
+	res := make(map[string]*Record, len(ids))
+	for start := 0; start < len(ids); start += sqliteBatchSize {
+		batch := ids[start:min(start+sqliteBatchSize, len(ids))]
+
+		query := fmt.Sprintf(`SELECT id, source, hash, updated_at FROM %s WHERE id IN (%s)`, s.table, placeholders(len(batch)))
+		rows, err := s.db.QueryContext(ctx, query, toArgs(batch)...)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var (
+				r         Record
+				updatedAt int64
+			)
+			if err = rows.Scan(&r.ID, &r.Source, &r.Hash, &updatedAt); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			r.UpdatedAt = time.UnixMilli(updatedAt)
+			res[r.ID] = &r
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *SQLiteStore) Upsert(ctx context.Context, records []*Record) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(`INSERT INTO %s (id, source, hash, updated_at) VALUES (?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET source = excluded.source, hash = excluded.hash, updated_at = excluded.updated_at`, s.table))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range records {
+		if _, err = stmt.ExecContext(ctx, r.ID, r.Source, r.Hash, r.UpdatedAt.UnixMilli()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) ListIDsBySource(ctx context.Context, source string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`SELECT id FROM %s WHERE source = ? ORDER BY id`, s.table), source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, ids []string) error {
+	for start := 0; start < len(ids); start += sqliteBatchSize {
+		batch := ids[start:min(start+sqliteBatchSize, len(ids))]
+
+		query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s)`, s.table, placeholders(len(batch)))
+		if _, err := s.db.ExecContext(ctx, query, toArgs(batch)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func toArgs(ids []string) []any {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"time"
+)
+
+// Record is what the record manager remembers about an ingested document.
+type Record struct {
+	// ID is the document ID, which is also the ID used in the indexer.
+	ID string `json:"id"`
+	// Source is the source uri the document was loaded from, e.g. the file path or s3 uri.
+	Source string `json:"source"`
+	// Hash is the content hash of the document when it was stored.
+	Hash string `json:"hash"`
+	// UpdatedAt is the time the record was last written.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store persists records, implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the records of the given ids, ids without a record are absent from the result.
+	Get(ctx context.Context, ids []string) (map[string]*Record, error)
+	// Upsert creates or overwrites the records by id.
+	Upsert(ctx context.Context, records []*Record) error
+	// ListIDsBySource returns the ids of all records of the source.
+	ListIDsBySource(ctx context.Context, source string) ([]string, error)
+	// Delete removes the records of the given ids, missing ids are ignored.
+	Delete(ctx context.Context, ids []string) error
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recordmanager
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+	now := time.UnixMilli(time.Now().UnixMilli())
+
+	assert.NoError(t, s.Upsert(ctx, []*Record{
+		{ID: "a_0", Source: "a.md", Hash: "h0", UpdatedAt: now},
+		{ID: "a_1", Source: "a.md", Hash: "h1", UpdatedAt: now},
+		{ID: "b_0", Source: "b.md", Hash: "h2", UpdatedAt: now},
+	}))
+
+	records, err := s.Get(ctx, []string{"a_0", "b_0", "missing"})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "a.md", records["a_0"].Source)
+	assert.Equal(t, "h2", records["b_0"].Hash)
+	assert.True(t, now.Equal(records["b_0"].UpdatedAt))
+
+	assert.NoError(t, s.Upsert(ctx, []*Record{{ID: "a_0", Source: "a.md", Hash: "h0'", UpdatedAt: now}}))
+	records, err = s.Get(ctx, []string{"a_0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "h0'", records["a_0"].Hash)
+
+	ids, err := s.ListIDsBySource(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0", "a_1"}, ids)
+
+	assert.NoError(t, s.Delete(ctx, []string{"a_1", "missing"}))
+	ids, err = s.ListIDsBySource(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a_0"}, ids)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	s, err := NewFileStore(path)
+	assert.NoError(t, err)
+	testStore(t, s)
+
+	// records survive a reopen.
+	s, err = NewFileStore(path)
+	assert.NoError(t, err)
+	ids, err := s.ListIDsBySource(context.Background(), "b.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b_0"}, ids)
+}
+
+func TestSQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "records.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = NewSQLiteStore(ctx, db, "bad name")
+	assert.Error(t, err)
+
+	s, err := NewSQLiteStore(ctx, db, "")
+	assert.NoError(t, err)
+	testStore(t, s)
+
+	// creating the store again keeps the existing table.
+	s, err = NewSQLiteStore(ctx, db, "")
+	assert.NoError(t, err)
+	ids, err := s.ListIDsBySource(ctx, "b.md")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b_0"}, ids)
+}
@@ -31,6 +31,10 @@ type ImplOptions struct {
 	// Optional, and the default value is nil
 	// It's means the milvus search extra search options, and refer to client.SearchQueryOptionFunc
 	SearchQueryOptFn func(option *client.SearchQueryOption)
+
+	// ReturnVectors returns the vectors with the documents
+	// Optional, and the default value is RetrieverConfig.ReturnVectors
+	ReturnVectors bool
 }
 
 func WithFilter(filter string) retriever.Option {
@@ -44,3 +48,9 @@ func WithSearchQueryOptFn(f func(option *client.SearchQueryOption)) retriever.Op
 		o.SearchQueryOptFn = f
 	})
 }
+
+func WithReturnVectors(returnVectors bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.ReturnVectors = returnVectors
+	})
+}
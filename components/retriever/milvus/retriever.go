@@ -66,6 +66,10 @@ type RetrieverConfig struct {
 	// SearchParams
 	// Optional, and the default value is entity.IndexAUTOINDEXSearchParam, and the level is 1
 	Sp entity.SearchParam
+	// ReturnVectors returns the vectors of the VectorField with the documents, set with schema.Document.WithDenseVector,
+	// e.g. for a diversity reranker. Binary vectors are decoded as written by the default VectorConverter
+	// Optional, and the default value is false, it can be overridden per call by WithReturnVectors
+	ReturnVectors bool
 	
 	// Embedding is the embedding vectorization method for values needs to be embedded from schema.Document's content.
 	// Required
@@ -145,6 +149,7 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 			TopK:              config.TopK,
 			ScoreThreshold:    config.ScoreThreshold,
 			Sp:                config.Sp,
+			ReturnVectors:     config.ReturnVectors,
 			Embedding:         config.Embedding,
 		},
 	}, nil
@@ -159,7 +164,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.Embedding,
 	}, opts...)
 	// get impl specific options
-	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	io := retriever.GetImplSpecificOptions(&ImplOptions{ReturnVectors: r.config.ReturnVectors}, opts...)
 	
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	// callback info on start
@@ -207,12 +212,16 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		searchParams = append(searchParams, io.SearchQueryOptFn)
 	}
 	
+	outputFields := r.config.OutputFields
+	if io.ReturnVectors {
+		outputFields = append(append(make([]string, 0, len(outputFields)+1), outputFields...), r.config.VectorField)
+	}
 	results, err = r.config.Client.Search(
 		ctx,
 		r.config.Collection,
 		r.config.Partition,
 		io.Filter,
-		r.config.OutputFields,
+		outputFields,
 		vec,
 		r.config.VectorField,
 		r.config.MetricType,
@@ -241,6 +250,11 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		if err != nil {
 			return nil, fmt.Errorf("[milvus retriever] failed to convert search result to schema.Document: %w", err)
 		}
+		if io.ReturnVectors {
+			if err := setDenseVectors(document, result.Fields.GetColumn(r.config.VectorField)); err != nil {
+				return nil, fmt.Errorf("[milvus retriever] failed to get vectors: %w", err)
+			}
+		}
 		documents = append(documents, document...)
 	}
 	
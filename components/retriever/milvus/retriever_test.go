@@ -24,6 +24,7 @@ import (
 
 	. "github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 	"github.com/smartystreets/goconvey/convey"
@@ -414,6 +415,31 @@ func TestRetriever_Retrieve(t *testing.T) {
 	})
 }
 
+func TestSetDenseVectors(t *testing.T) {
+	convey.Convey("test setDenseVectors", t, func() {
+		docs := []*schema.Document{{ID: "1"}, {ID: "2"}}
+
+		convey.Convey("test float vectors", func() {
+			column := entity.NewColumnFloatVector("vector", 2, [][]float32{{0.5, 1}, {0.25, 2}})
+			convey.So(setDenseVectors(docs, column), convey.ShouldBeNil)
+			convey.So(docs[0].DenseVector(), convey.ShouldResemble, []float64{0.5, 1})
+			convey.So(docs[1].DenseVector(), convey.ShouldResemble, []float64{0.25, 2})
+		})
+
+		convey.Convey("test binary vectors written by the default vector converter", func() {
+			column := entity.NewColumnBinaryVector("vector", 64, [][]byte{vector2Bytes([]float64{0.5, 1}), vector2Bytes([]float64{0.25, 2})})
+			convey.So(setDenseVectors(docs, column), convey.ShouldBeNil)
+			convey.So(docs[1].DenseVector(), convey.ShouldResemble, []float64{0.25, 2})
+		})
+
+		convey.Convey("test missing vectors", func() {
+			convey.So(setDenseVectors(docs, nil), convey.ShouldNotBeNil)
+			column := entity.NewColumnFloatVector("vector", 2, [][]float32{{0.5, 1}})
+			convey.So(setDenseVectors(docs, column), convey.ShouldNotBeNil)
+		})
+	})
+}
+
 type mockEmbedding struct {
 	err         error
 	cnt         int
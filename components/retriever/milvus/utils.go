@@ -53,6 +53,10 @@ func defaultDocumentConverter() func(ctx context.Context, doc client.SearchResul
 			}
 		}
 		for _, field := range doc.Fields {
+			if isVectorColumn(field) {
+				// vectors are set by the retriever if ReturnVectors
+				continue
+			}
 			switch field.Name() {
 			case "id":
 				for i, document := range result {
@@ -100,6 +104,43 @@ func defaultVectorConverter() func(ctx context.Context, vectors [][]float64) ([]
 	}
 }
 
+// isVectorColumn reports whether the column holds vectors
+func isVectorColumn(column entity.Column) bool {
+	switch column.Type() {
+	case entity.FieldTypeFloatVector, entity.FieldTypeBinaryVector:
+		return true
+	default:
+		return false
+	}
+}
+
+// setDenseVectors sets the vectors of the column to the documents converted from the same search result
+func setDenseVectors(docs []*schema.Document, column entity.Column) error {
+	if column == nil {
+		return errors.New("vector field not returned")
+	}
+	if column.Len() != len(docs) {
+		return fmt.Errorf("got %d vectors for %d documents", column.Len(), len(docs))
+	}
+	switch c := column.(type) {
+	case *entity.ColumnFloatVector:
+		for i, v := range c.Data() {
+			vector := make([]float64, len(v))
+			for j, f := range v {
+				vector[j] = float64(f)
+			}
+			docs[i].WithDenseVector(vector)
+		}
+	case *entity.ColumnBinaryVector:
+		for i, v := range c.Data() {
+			docs[i].WithDenseVector(bytes2Vector(v))
+		}
+	default:
+		return fmt.Errorf("unsupported vector type: %v", column.Type())
+	}
+	return nil
+}
+
 // checkCollectionSchema checks if the vector field exists in the schema
 func checkCollectionSchema(field string, s *entity.Schema) error {
 	for _, column := range s.Fields {
@@ -194,3 +235,12 @@ func vector2Bytes(vector []float64) []byte {
 	}
 	return bytes
 }
+
+// bytes2Vector converts the bytes written by vector2Bytes back to the vector
+func bytes2Vector(bytes []byte) []float64 {
+	vector := make([]float64, len(bytes)/4)
+	for i := range vector {
+		vector[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(bytes[i*4:])))
+	}
+	return vector
+}
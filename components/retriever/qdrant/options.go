@@ -22,7 +22,8 @@ import (
 )
 
 type implOptions struct {
-	Filter *qdrant.Filter
+	Filter        *qdrant.Filter
+	ReturnVectors bool
 }
 
 // WithFilter sets a Qdrant filter for the search query.
@@ -32,3 +33,10 @@ func WithFilter(filter *qdrant.Filter) retriever.Option {
 		o.Filter = filter
 	})
 }
+
+// WithReturnVectors overrides Config.ReturnVectors for one call.
+func WithReturnVectors(returnVectors bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.ReturnVectors = returnVectors
+	})
+}
@@ -40,6 +40,9 @@ type Config struct {
 	ScoreThreshold *float64
 	// Number of top results to retrieve from Qdrant.
 	TopK int
+	// ReturnVectors returns the vectors of the points with the documents, set with schema.Document.WithDenseVector,
+	// e.g. for a diversity reranker. Can be overridden per call by WithReturnVectors.
+	ReturnVectors bool
 }
 
 type Retriever struct {
@@ -48,6 +51,7 @@ type Retriever struct {
 	embedding      embedding.Embedder
 	scoreThreshold *float64
 	topK           int
+	returnVectors  bool
 }
 
 func NewRetriever(ctx context.Context, config *Config) (*Retriever, error) {
@@ -75,6 +79,7 @@ func NewRetriever(ctx context.Context, config *Config) (*Retriever, error) {
 		embedding:      config.Embedding,
 		scoreThreshold: config.ScoreThreshold,
 		topK:           topK,
+		returnVectors:  config.ReturnVectors,
 	}, nil
 }
 
@@ -84,7 +89,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		ScoreThreshold: r.scoreThreshold,
 		Embedding:      r.embedding,
 	}, opts...)
-	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{ReturnVectors: r.returnVectors}, opts...)
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -127,6 +132,9 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	if io.Filter != nil {
 		searchReq.Filter = io.Filter
 	}
+	if io.ReturnVectors {
+		searchReq.WithVectors = qdrant.NewWithVectors(true)
+	}
 
 	resp, err := r.client.Query(ctx, &searchReq)
 	if err != nil {
@@ -149,6 +157,12 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 
 		doc.WithScore(float64(pt.Score))
 
+		if io.ReturnVectors {
+			if vec := denseVector(pt.Vectors.GetVector()); len(vec) > 0 {
+				doc.WithDenseVector(vec)
+			}
+		}
+
 		docs = append(docs, doc)
 	}
 
@@ -171,6 +185,20 @@ func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder
 	return callbacks.ReuseHandlers(ctx, runInfo)
 }
 
+// denseVector converts the default dense vector of a point to float64.
+func denseVector(v *qdrant.VectorOutput) []float64 {
+	data := v.GetDense().GetData()
+	if len(data) == 0 {
+		// servers before 1.13 only fill the deprecated data field
+		data = v.GetData()
+	}
+	vec := make([]float64, len(data))
+	for i, f := range data {
+		vec[i] = float64(f)
+	}
+	return vec
+}
+
 func tryMarshalJsonString(input any) string {
 	if input == nil {
 		return ""
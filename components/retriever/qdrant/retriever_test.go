@@ -145,6 +145,55 @@ func TestRetrieverRetrieve(t *testing.T) {
 	})
 }
 
+func TestRetrieverReturnVectors(t *testing.T) {
+	PatchConvey("TestRetrieverReturnVectors", t, func() {
+		ctx := context.Background()
+		mockEmbedding := &mockEmbeddingQdrant{dims: 2}
+		mockClient := &qdrant.Client{}
+
+		var withVectors *qdrant.WithVectorsSelector
+		Mock((*qdrant.Client).Query).To(func(c *qdrant.Client, ctx context.Context, req *qdrant.QueryPoints) ([]*qdrant.ScoredPoint, error) {
+			withVectors = req.WithVectors
+			return []*qdrant.ScoredPoint{
+				{
+					Id:    qdrant.NewID("fba95545-ef38-4880-bf4a-b98174554103"),
+					Score: 0.95,
+					Payload: map[string]*qdrant.Value{
+						defaultContentKey: qdrant.NewValueString("Test content 1"),
+					},
+					Vectors: &qdrant.VectorsOutput{
+						VectorsOptions: &qdrant.VectorsOutput_Vector{
+							Vector: &qdrant.VectorOutput{Data: []float32{0.5, 0.25}},
+						},
+					},
+				},
+			}, nil
+		}).Build()
+
+		retriever, err := NewRetriever(ctx, &Config{
+			Client:        mockClient,
+			Collection:    CollectionName,
+			Embedding:     mockEmbedding,
+			ReturnVectors: true,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When retrieving documents with vectors", func() {
+			docs, err := retriever.Retrieve(ctx, "test query")
+			So(err, ShouldBeNil)
+			So(withVectors, ShouldNotBeNil)
+			So(docs[0].DenseVector(), ShouldResemble, []float64{0.5, 0.25})
+		})
+
+		Convey("When vectors are disabled for one call", func() {
+			docs, err := retriever.Retrieve(ctx, "test query", WithReturnVectors(false))
+			So(err, ShouldBeNil)
+			So(withVectors, ShouldBeNil)
+			So(docs[0].DenseVector(), ShouldBeNil)
+		})
+	})
+}
+
 func TestRetrieverRetrieveWithError(t *testing.T) {
 	PatchConvey("TestRetrieverRetrieveWithError", t, func() {
 		ctx := context.Background()